@@ -0,0 +1,26 @@
+// Package cache guarda respostas caras de gerar (como as análises com IA) com
+// tempo de expiração e invalidação por grupo
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Tipos de store disponíveis
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+	StoreOff      = "off"
+)
+
+// Store guarda valores por chave; cada entrada pertence a um grupo (ex.:
+// "player:1") para que todas as entradas relacionadas possam ser invalidadas juntas
+type Store interface {
+	// Get retorna o valor da chave; ok é false quando ela não existe ou expirou
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set grava o valor no grupo; ttl zero ou negativo não expira
+	Set(ctx context.Context, key, group string, value []byte, ttl time.Duration) error
+	// InvalidateGroup remove todas as entradas do grupo
+	InvalidateGroup(ctx context.Context, group string) error
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Entry é uma entrada do cache persistida no banco
+type Entry struct {
+	Key       string     `gorm:"column:cache_key;primaryKey;size:128"`
+	GroupName string     `gorm:"not null;index"`
+	Value     []byte     `gorm:"not null"`
+	ExpiresAt *time.Time `gorm:"index"` // nil não expira
+	CreatedAt time.Time
+}
+
+// TableName especifica o nome da tabela
+func (Entry) TableName() string {
+	return "cache_entries"
+}
+
+// DBStore é um Store no banco da aplicação (Postgres em produção): as
+// entradas sobrevivem a reinícios e são compartilhadas entre instâncias
+type DBStore struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewDBStore cria o store e a tabela cache_entries, se necessário
+func NewDBStore(db *gorm.DB) (*DBStore, error) {
+	if err := db.AutoMigrate(&Entry{}); err != nil {
+		return nil, err
+	}
+	return &DBStore{db: db, now: time.Now}, nil
+}
+
+// Get retorna o valor da chave; entradas expiradas são removidas ao serem lidas
+func (s *DBStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var entry Entry
+	err := s.db.WithContext(ctx).Where("cache_key = ?", key).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if entry.ExpiresAt != nil && !s.now().Before(*entry.ExpiresAt) {
+		err := s.db.WithContext(ctx).
+			Where("cache_key = ? AND expires_at = ?", key, *entry.ExpiresAt).
+			Delete(&Entry{}).Error
+		return nil, false, err
+	}
+	return entry.Value, true, nil
+}
+
+// Set grava ou substitui o valor da chave
+func (s *DBStore) Set(ctx context.Context, key, group string, value []byte, ttl time.Duration) error {
+	entry := Entry{Key: key, GroupName: group, Value: value, CreatedAt: s.now()}
+	if ttl > 0 {
+		expiresAt := s.now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cache_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"group_name", "value", "expires_at", "created_at"}),
+	}).Create(&entry).Error
+}
+
+// InvalidateGroup remove as entradas do grupo
+func (s *DBStore) InvalidateGroup(ctx context.Context, group string) error {
+	return s.db.WithContext(ctx).Where("group_name = ?", group).Delete(&Entry{}).Error
+}
+
+// DeleteExpired remove as entradas expiradas que não foram lidas desde que expiraram
+func (s *DBStore) DeleteExpired(ctx context.Context) error {
+	return s.db.WithContext(ctx).Where("expires_at <= ?", s.now()).Delete(&Entry{}).Error
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBStore(t *testing.T) *DBStore {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewDBStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestDBStore(t *testing.T) {
+	ctx := context.Background()
+	store := setupDBStore(t)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	assert.NoError(t, store.Set(ctx, "a", "player:1", []byte("primeira"), time.Hour))
+	assert.NoError(t, store.Set(ctx, "a", "player:1", []byte("segunda"), time.Hour))
+	assert.NoError(t, store.Set(ctx, "b", "player:2", []byte("sem prazo"), 0))
+
+	value, ok, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("segunda"), value)
+
+	// Expirada: não é retornada e sai do banco
+	now = now.Add(time.Hour)
+	_, ok, err = store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	var count int64
+	store.db.Model(&Entry{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	assert.NoError(t, store.InvalidateGroup(ctx, "player:2"))
+	_, ok, _ = store.Get(ctx, "b")
+	assert.False(t, ok)
+}
+
+func TestDBStoreDeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	store := setupDBStore(t)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	store.Set(ctx, "a", "g", []byte("1"), time.Minute)
+	store.Set(ctx, "b", "g", []byte("2"), time.Hour)
+
+	now = now.Add(10 * time.Minute)
+	assert.NoError(t, store.DeleteExpired(ctx))
+
+	var keys []string
+	store.db.Model(&Entry{}).Pluck("cache_key", &keys)
+	assert.Equal(t, []string{"b"}, keys)
+}
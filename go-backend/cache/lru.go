@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultLRUSize é a quantidade padrão de entradas do cache em memória
+const DefaultLRUSize = 1000
+
+// LRU é um Store em memória que descarta as entradas usadas há mais tempo
+// quando atinge a capacidade
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	groups  map[string]map[string]struct{}
+	order   *list.List // mais recente na frente
+
+	now func() time.Time
+}
+
+// lruEntry é uma entrada do LRU
+type lruEntry struct {
+	key       string
+	group     string
+	value     []byte
+	expiresAt time.Time // zero não expira
+}
+
+// NewLRU cria o cache em memória; size <= 0 usa DefaultLRUSize
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		size = DefaultLRUSize
+	}
+	return &LRU{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		groups:  make(map[string]map[string]struct{}),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get retorna o valor da chave e a marca como usada recentemente
+func (l *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !l.now().Before(entry.expiresAt) {
+		l.remove(element)
+		return nil, false, nil
+	}
+
+	l.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set grava o valor, descartando a entrada menos usada se o cache estiver cheio
+func (l *LRU) Set(ctx context.Context, key, group string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+
+	entry := &lruEntry{key: key, group: group, value: value}
+	if ttl > 0 {
+		entry.expiresAt = l.now().Add(ttl)
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	if l.groups[group] == nil {
+		l.groups[group] = make(map[string]struct{})
+	}
+	l.groups[group][key] = struct{}{}
+
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+	return nil
+}
+
+// InvalidateGroup remove as entradas do grupo
+func (l *LRU) InvalidateGroup(ctx context.Context, group string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key := range l.groups[group] {
+		l.remove(l.entries[key])
+	}
+	return nil
+}
+
+// Len retorna a quantidade de entradas, inclusive as expiradas ainda não descartadas
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// remove descarta a entrada; deve ser chamado com o mutex travado
+func (l *LRU) remove(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	l.order.Remove(element)
+	delete(l.entries, entry.key)
+
+	if keys := l.groups[entry.group]; keys != nil {
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(l.groups, entry.group)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(2)
+
+	lru.Set(ctx, "a", "g", []byte("1"), 0)
+	lru.Set(ctx, "b", "g", []byte("2"), 0)
+
+	// "a" passa a ser a mais recente; "b" é descartada ao inserir "c"
+	_, ok, _ := lru.Get(ctx, "a")
+	assert.True(t, ok)
+	lru.Set(ctx, "c", "g", []byte("3"), 0)
+
+	_, ok, _ = lru.Get(ctx, "b")
+	assert.False(t, ok)
+	value, ok, _ := lru.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRUExpiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	lru := NewLRU(10)
+	lru.now = func() time.Time { return now }
+
+	lru.Set(ctx, "a", "g", []byte("1"), time.Minute)
+	lru.Set(ctx, "b", "g", []byte("2"), 0)
+
+	now = now.Add(time.Minute)
+	_, ok, _ := lru.Get(ctx, "a")
+	assert.False(t, ok)
+	_, ok, _ = lru.Get(ctx, "b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, lru.Len())
+}
+
+func TestLRUInvalidateGroup(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+
+	lru.Set(ctx, "a", "player:1", []byte("1"), 0)
+	lru.Set(ctx, "b", "player:1", []byte("2"), 0)
+	lru.Set(ctx, "c", "player:2", []byte("3"), 0)
+
+	// Regravar a chave em outro grupo a tira do grupo anterior
+	lru.Set(ctx, "b", "player:2", []byte("2"), 0)
+
+	assert.NoError(t, lru.InvalidateGroup(ctx, "player:1"))
+	_, ok, _ := lru.Get(ctx, "a")
+	assert.False(t, ok)
+	_, ok, _ = lru.Get(ctx, "b")
+	assert.True(t, ok)
+	_, ok, _ = lru.Get(ctx, "c")
+	assert.True(t, ok)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mvcbotelho/scout-ai/cache"
+	"github.com/mvcbotelho/scout-ai/eval"
+	"github.com/mvcbotelho/scout-ai/handlers"
+)
+
+// runCommand executa um subcomando de linha de comando em vez do servidor
+func runCommand(name string, args []string) {
+	switch name {
+	case "import":
+		runImportCommand(args)
+	case "eval":
+		runEvalCommand(args)
+	default:
+		fmt.Fprintf(os.Stderr, "Comando desconhecido: %s\n\nComandos disponíveis:\n  import  Importa jogadores de um arquivo CSV ou NDJSON\n  eval    Avalia e compara modelos e prompts com um conjunto fixo de jogadores\n", name)
+		os.Exit(2)
+	}
+}
+
+// runImportCommand importa jogadores de um arquivo, com as mesmas regras do POST /players/import
+func runImportCommand(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "formato do arquivo: csv ou ndjson (padrão: pela extensão)")
+	dryRun := flags.Bool("dry-run", false, "valida e simula a importação sem gravar")
+	atomic := flags.Bool("atomic", false, "desfaz toda a importação se alguma linha for rejeitada")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Uso: main import [opções] <arquivo | ->")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	path := flags.Arg(0)
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatal("Erro ao abrir arquivo: ", err)
+		}
+		defer file.Close()
+		reader = file
+
+		if *format == "" {
+			*format = handlers.ImportFormatFromFilename(path)
+		}
+	}
+
+	if *format == "" {
+		log.Fatal("Formato não identificado: use -format csv ou -format ndjson")
+	}
+
+	db := connectDatabase()
+	migrateDatabase(db)
+
+	// Com o cache no banco, a importação invalida as análises dos jogadores alterados
+	if getEnv("AI_CACHE", cache.StoreMemory) == cache.StorePostgres {
+		if store, err := cache.NewDBStore(db); err == nil {
+			handlers.AICache.Store = store
+		}
+	}
+
+	report, err := handlers.RunPlayerImport(db, reader, handlers.ImportOptions{
+		Format: *format,
+		DryRun: *dryRun,
+		Atomic: *atomic,
+	})
+	if err != nil {
+		log.Fatal("Erro ao importar jogadores: ", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+
+	log.Printf("Importação concluída: %d criados, %d atualizados, %d sem alteração, %d rejeitados (gravado: %t)",
+		report.Created, report.Updated, report.Unchanged, report.Rejected, report.Committed)
+}
+
+// runEvalCommand avalia a configuração de referência e, se informada, a
+// candidata, e escreve o relatório da comparação
+func runEvalCommand(args []string) {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	fixtures := flags.String("fixtures", "", "arquivo JSON com os casos de avaliação (padrão: conjunto embutido)")
+	judgePath := flags.String("judge", "", "configuração do modelo juiz, que dá nota de 1 a 5 a cada análise (padrão: sem juiz)")
+	format := flags.String("format", "markdown", "formato do relatório: markdown ou json")
+	output := flags.String("out", "", "arquivo do relatório (padrão: saída padrão)")
+	workers := flags.Int("workers", eval.DefaultOptions.Workers, "jogadores analisados ao mesmo tempo")
+	minWords := flags.Int("min-words", eval.DefaultOptions.MinWords, "mínimo de palavras do resumo")
+	maxWords := flags.Int("max-words", eval.DefaultOptions.MaxWords, "máximo de palavras do resumo")
+	failOnRegression := flags.Bool("fail-on-regression", false, "termina com código 1 se a candidata for pior que a referência")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Uso: main eval [opções] <referência.json | fake> [candidata.json | fake]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() < 1 || flags.NArg() > 2 || (*format != "markdown" && *format != "json") {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	cases, err := eval.LoadCases(*fixtures)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	options := eval.DefaultOptions
+	options.Workers = *workers
+	options.MinWords = *minWords
+	options.MaxWords = *maxWords
+	if *judgePath != "" {
+		config, err := eval.LoadConfig(*judgePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if options.Judge, _, err = config.Open(); err != nil {
+			log.Fatal("Erro ao configurar o juiz: ", err)
+		}
+	}
+
+	var results []eval.Result
+	for _, path := range flags.Args() {
+		config, err := eval.LoadConfig(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		provider, registry, err := config.Open()
+		if err != nil {
+			log.Fatalf("Erro ao configurar %s: %v", config.Name, err)
+		}
+
+		log.Printf("Avaliando %s (%s, %d casos)", config.Name, provider.Name(), len(cases))
+		result := eval.Run(context.Background(), config.Name, provider, registry, cases, options)
+		log.Printf("%s: pontuação %.0f%%, %d erros", config.Name, result.Summary.Score*100, result.Summary.Errors)
+		results = append(results, result)
+	}
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatal("Erro ao criar o relatório: ", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	var report interface{} = results[0]
+	var comparison eval.Comparison
+	if len(results) == 2 {
+		comparison = eval.Compare(results[0], results[1])
+		report = comparison
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else if len(results) == 2 {
+		eval.WriteComparison(writer, comparison)
+	} else {
+		eval.WriteResult(writer, results[0])
+	}
+
+	if *failOnRegression && comparison.Verdict == eval.VerdictWorse {
+		log.Printf("%s é pior que %s", results[1].Config, results[0].Config)
+		os.Exit(1)
+	}
+}
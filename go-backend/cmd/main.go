@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,16 +9,265 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/cache"
 	"github.com/mvcbotelho/scout-ai/handlers"
+	"github.com/mvcbotelho/scout-ai/llm"
 	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func main() {
+	// Subcomandos de linha de comando (ex.: go run ./cmd import jogadores.csv)
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	r := gin.Default()
 
-	// Conecta no banco usando variáveis de ambiente
+	db := connectDatabase()
+
+	// Configurar o provedor de LLM das análises com IA
+	provider := configureLLM()
+
+	// Exigir If-Match nas alterações de jogadores (controle de concorrência)
+	handlers.PlayerConcurrency.RequireIfMatch = getEnv("REQUIRE_IF_MATCH", "false") == "true"
+
+	// Token das operações administrativas (ex.: DELETE /players/:id?purge=true)
+	handlers.AdminAccess.Token = getEnv("ADMIN_TOKEN", "")
+
+	migrateDatabase(db)
+
+	// Cache dos textos gerados pela IA
+	configureCache(db)
+
+	// Templates dos prompts enviados ao modelo
+	configurePrompts()
+
+	// Memória das conversas com o assistente de scouting
+	if messages, err := strconv.Atoi(getEnv("CHAT_HISTORY_MESSAGES", strconv.Itoa(handlers.ChatMemory.HistoryMessages))); err == nil {
+		handlers.ChatMemory.HistoryMessages = messages
+	}
+	if players, err := strconv.Atoi(getEnv("CHAT_MAX_PLAYERS", strconv.Itoa(handlers.ChatMemory.MaxPlayers))); err == nil {
+		handlers.ChatMemory.MaxPlayers = players
+	}
+	if analyses, err := strconv.Atoi(getEnv("CHAT_ANALYSES_PER_PLAYER", strconv.Itoa(handlers.ChatMemory.AnalysesPerPlayer))); err == nil {
+		handlers.ChatMemory.AnalysesPerPlayer = analyses
+	}
+
+	// Lotes de análise processados em segundo plano
+	workers, _ := strconv.Atoi(getEnv("ANALYSIS_JOB_WORKERS", strconv.Itoa(handlers.DefaultJobWorkers)))
+	jobRunner := handlers.NewJobRunner(db, provider, workers)
+	if err := jobRunner.Start(context.Background()); err != nil {
+		log.Printf("Aviso: Erro ao retomar lotes de análise: %v", err)
+	}
+
+	// Endpoints básicos
+	r.GET("/ping", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "pong"})
+	})
+	r.GET("/health", handlers.Health(db, provider))
+
+	// Endpoints de jogadores
+	r.POST("/players", handlers.CreatePlayer(db))
+	r.GET("/players", handlers.GetPlayers(db))
+	r.GET("/players/search", handlers.SearchPlayers(db))
+	r.POST("/players/import", handlers.ImportPlayers(db))
+	r.GET("/players/export", handlers.ExportPlayers(db))
+	r.GET("/players/:id", handlers.GetPlayerByID(db))
+	r.PUT("/players/:id", handlers.UpdatePlayer(db))
+	r.PATCH("/players/:id", handlers.PatchPlayer(db))
+	r.DELETE("/players/:id", handlers.DeletePlayer(db))
+	r.POST("/players/:id/restore", handlers.RestorePlayer(db))
+
+	// Endpoints de atuações por partida
+	r.POST("/players/:id/appearances", handlers.CreateAppearance(db))
+	r.GET("/players/:id/appearances", handlers.GetPlayerAppearances(db))
+	r.GET("/appearances/:id", handlers.GetAppearanceByID(db))
+	r.PUT("/appearances/:id", handlers.UpdateAppearance(db))
+	r.DELETE("/appearances/:id", handlers.DeleteAppearance(db))
+
+	// Histórico de análises
+	r.GET("/players/:id/analyses", handlers.GetPlayerAnalyses(db))
+	r.GET("/players/:id/analyses/diff", handlers.DiffPlayerAnalyses(db))
+	r.GET("/analyses/:id", handlers.GetAnalysisByID(db))
+
+	// Endpoints de temporadas
+	r.POST("/seasons", handlers.CreateSeason(db))
+	r.GET("/seasons", handlers.GetSeasons(db))
+	r.DELETE("/seasons/:id", handlers.DeleteSeason(db))
+
+	// Endpoints de análise
+	r.GET("/analyze/players/:id", handlers.AnalyzePlayer(db, provider))
+	r.GET("/analyze/players/:id/seasons", handlers.CompareSeasons(db))
+	r.GET("/analyze/players/:id/report.pdf", handlers.PlayerReport(db, provider))
+	r.GET("/analyze/players/:id/stream", handlers.StreamPlayerAnalysis(db, provider))
+	r.GET("/analyze/players", handlers.AnalyzeAllPlayers(db, provider))
+	r.GET("/analyze/players/export", handlers.ExportAnalyses(db))
+	r.GET("/analyze/compare", handlers.ComparePlayers(db, provider))
+	r.POST("/analyze/jobs", handlers.CreateAnalysisJob(db, jobRunner))
+	r.GET("/analyze/jobs/:id", handlers.GetAnalysisJob(db))
+
+	// Conversas com o assistente de scouting
+	r.POST("/chat/sessions", handlers.CreateChatSession(db))
+	r.GET("/chat/sessions", handlers.ListChatSessions(db))
+	r.GET("/chat/sessions/:id", handlers.GetChatSession(db))
+	r.DELETE("/chat/sessions/:id", handlers.DeleteChatSession(db))
+	r.POST("/chat/sessions/:id/messages", handlers.SendChatMessage(db, provider))
+
+	// Templates de prompt (administração)
+	r.GET("/admin/prompts", handlers.ListPrompts())
+	r.POST("/admin/prompts/reload", handlers.ReloadPrompts())
+	r.GET("/admin/prompts/:name/preview", handlers.PreviewPrompt(db))
+
+	log.Println("Servidor iniciado na porta 8080")
+	log.Println("LLM configurado:", provider.Name())
+	r.Run(":8080")
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// configureLLM cria o provedor de LLM a partir das variáveis de ambiente.
+// As variáveis OLLAMA_* continuam valendo para o provedor padrão (Ollama)
+func configureLLM() llm.Provider {
+	config := llm.DefaultConfig
+	config.Provider = getEnv("LLM_PROVIDER", llm.ProviderOllama)
+	config.Model = getEnv("LLM_MODEL", getEnv("OLLAMA_MODEL", config.Model))
+	config.APIKey = getEnv("LLM_API_KEY", "")
+
+	// Tempo limite de cada chamada ao modelo (ex.: 45s, 2m; 0 desabilita)
+	if timeout, err := time.ParseDuration(getEnv("LLM_TIMEOUT", "60s")); err == nil {
+		config.Timeout = timeout
+	} else {
+		log.Printf("Aviso: LLM_TIMEOUT inválido, usando %s: %v", config.Timeout, err)
+	}
+
+	// Máximo de chamadas simultâneas ao modelo, somando requisições e lotes (0 desabilita)
+	if limit, err := strconv.Atoi(getEnv("LLM_MAX_CONCURRENCY", strconv.Itoa(config.MaxConcurrency))); err == nil {
+		config.MaxConcurrency = limit
+	}
+
+	// Análises com IA em paralelo em GET /analyze/players e prazo de cada jogador
+	if workers, err := strconv.Atoi(getEnv("AI_ANALYZE_WORKERS", strconv.Itoa(handlers.DefaultAnalyzeWorkers))); err == nil {
+		handlers.AnalyzeAllFanOut.Workers = workers
+	}
+	if timeout, err := time.ParseDuration(getEnv("AI_PLAYER_TIMEOUT", "0s")); err == nil {
+		handlers.AnalyzeAllFanOut.PlayerTimeout = timeout
+	} else {
+		log.Printf("Aviso: AI_PLAYER_TIMEOUT inválido, sem prazo por jogador: %v", err)
+	}
+
+	// Novas tentativas em erros transitórios e circuit breaker
+	if retries, err := strconv.Atoi(getEnv("LLM_MAX_RETRIES", "2")); err == nil {
+		config.Retry.MaxRetries = retries
+	}
+	if delay, err := time.ParseDuration(getEnv("LLM_RETRY_BASE_DELAY", "500ms")); err == nil {
+		config.Retry.BaseDelay = delay
+	}
+	if threshold, err := strconv.Atoi(getEnv("LLM_BREAKER_THRESHOLD", "5")); err == nil {
+		config.Breaker.FailureThreshold = threshold
+	}
+	if cooldown, err := time.ParseDuration(getEnv("LLM_BREAKER_COOLDOWN", "30s")); err == nil {
+		config.Breaker.Cooldown = cooldown
+	}
+
+	// No tempo limite, usar a análise estática (fallback) ou responder 504 (error)
+	handlers.AIBehavior.FallbackOnTimeout = getEnv("LLM_ON_TIMEOUT", "fallback") != "error"
+
+	if config.Provider == llm.ProviderOllama {
+		config.BaseURL = getEnv("LLM_BASE_URL", getEnv("OLLAMA_BASE_URL", config.BaseURL))
+	} else {
+		config.BaseURL = getEnv("LLM_BASE_URL", "")
+	}
+
+	if temp := getEnv("LLM_TEMPERATURE", getEnv("OLLAMA_TEMPERATURE", "0.7")); temp != "" {
+		if temperature, err := strconv.ParseFloat(temp, 64); err == nil {
+			config.Temperature = temperature
+		}
+	}
+
+	if topP := getEnv("LLM_TOP_P", getEnv("OLLAMA_TOP_P", "0.9")); topP != "" {
+		if topPValue, err := strconv.ParseFloat(topP, 64); err == nil {
+			config.TopP = topPValue
+		}
+	}
+
+	provider, err := llm.New(config)
+	if err != nil {
+		log.Fatal("Erro ao configurar LLM: ", err)
+	}
+	return provider
+}
+
+// configurePrompts usa os templates de PROMPTS_DIR no lugar dos embutidos com
+// o mesmo nome e os recarrega quando os arquivos mudam
+func configurePrompts() {
+	dir := getEnv("PROMPTS_DIR", "")
+	if dir == "" {
+		return
+	}
+
+	registry, err := prompts.New(dir)
+	if err != nil {
+		log.Printf("Aviso: Erro ao carregar prompts de %s (usando os embutidos): %v", dir, err)
+		return
+	}
+	handlers.AIPrompts = registry
+
+	interval, err := time.ParseDuration(getEnv("PROMPTS_RELOAD_INTERVAL", "5s"))
+	if err != nil {
+		log.Printf("Aviso: PROMPTS_RELOAD_INTERVAL inválido, recarga automática desabilitada: %v", err)
+		return
+	}
+	go registry.Watch(context.Background(), interval)
+	log.Printf("Prompts carregados de %s", dir)
+}
+
+// configureCache configura o cache das análises com IA: AI_CACHE=memory (LRU,
+// padrão), postgres (tabela cache_entries, compartilhada entre instâncias) ou off
+func configureCache(db *gorm.DB) {
+	if ttl, err := time.ParseDuration(getEnv("AI_CACHE_TTL", "24h")); err == nil {
+		handlers.AICache.TTL = ttl
+	} else {
+		log.Printf("Aviso: AI_CACHE_TTL inválido, usando %s: %v", handlers.AICache.TTL, err)
+	}
+
+	switch store := getEnv("AI_CACHE", cache.StoreMemory); store {
+	case cache.StoreMemory:
+		size, _ := strconv.Atoi(getEnv("AI_CACHE_SIZE", strconv.Itoa(cache.DefaultLRUSize)))
+		handlers.AICache.Store = cache.NewLRU(size)
+	case cache.StorePostgres:
+		dbStore, err := cache.NewDBStore(db)
+		if err != nil {
+			log.Printf("Aviso: Erro ao preparar o cache no banco (cache desabilitado): %v", err)
+			return
+		}
+		handlers.AICache.Store = dbStore
+
+		// Entradas expiradas que ninguém voltou a ler são removidas periodicamente
+		go func() {
+			for range time.Tick(time.Hour) {
+				if err := dbStore.DeleteExpired(context.Background()); err != nil {
+					log.Printf("Erro ao limpar o cache de análises: %v", err)
+				}
+			}
+		}()
+	case cache.StoreOff:
+	default:
+		log.Printf("Aviso: AI_CACHE inválido (%s), cache desabilitado", store)
+	}
+	log.Printf("Cache de análises: %s (TTL %s)", getEnv("AI_CACHE", cache.StoreMemory), handlers.AICache.TTL)
+}
+
+// connectDatabase conecta no banco usando variáveis de ambiente
+func connectDatabase() *gorm.DB {
 	dbHost := getEnv("DB_HOST", "localhost")
 	dbPort := getEnv("DB_PORT", "5432")
 	dbUser := getEnv("DB_USER", "postgres")
@@ -49,14 +299,16 @@ func main() {
 		log.Fatal("Erro ao conectar no banco após 5 tentativas:", err)
 	}
 
-	// Configurar Ollama
-	configureOllama()
+	return db
+}
 
+// migrateDatabase cria e atualiza as tabelas e índices
+func migrateDatabase(db *gorm.DB) {
 	// Faz AutoMigrate com retry
 	log.Println("Iniciando migração do banco de dados...")
 	var migrationErr error
 	for i := 0; i < 3; i++ {
-		migrationErr = db.AutoMigrate(&models.Player{})
+		migrationErr = db.AutoMigrate(&models.Player{}, &models.Appearance{}, &models.Season{}, &models.Analysis{}, &models.AnalysisJob{}, &models.AnalysisJobItem{}, &models.ChatSession{}, &models.ChatMessage{})
 		if migrationErr == nil {
 			log.Println("Migração concluída com sucesso")
 			break
@@ -71,49 +323,8 @@ func main() {
 		log.Printf("Aviso: Erro na migração (continuando mesmo assim): %v", migrationErr)
 	}
 
-	// Endpoints básicos
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
-
-	// Endpoints de jogadores
-	r.POST("/players", handlers.CreatePlayer(db))
-	r.GET("/players", handlers.GetPlayers(db))
-	r.GET("/players/:id", handlers.GetPlayerByID(db))
-	r.PUT("/players/:id", handlers.UpdatePlayer(db))
-	r.DELETE("/players/:id", handlers.DeletePlayer(db))
-
-	// Endpoints de análise
-	r.GET("/analyze/players/:id", handlers.AnalyzePlayer(db))
-	r.GET("/analyze/players", handlers.AnalyzeAllPlayers(db))
-	r.GET("/analyze/compare", handlers.ComparePlayers(db))
-
-	log.Println("Servidor iniciado na porta 8080")
-	log.Println("Ollama configurado:", handlers.DefaultOllamaConfig.BaseURL)
-	r.Run(":8080")
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func configureOllama() {
-	// Configurar Ollama com variáveis de ambiente
-	handlers.DefaultOllamaConfig.BaseURL = getEnv("OLLAMA_BASE_URL", "http://localhost:11434")
-	handlers.DefaultOllamaConfig.Model = getEnv("OLLAMA_MODEL", "llama3.2")
-
-	if temp := getEnv("OLLAMA_TEMPERATURE", "0.7"); temp != "" {
-		if temperature, err := strconv.ParseFloat(temp, 64); err == nil {
-			handlers.DefaultOllamaConfig.Temperature = temperature
-		}
-	}
-
-	if topP := getEnv("OLLAMA_TOP_P", "0.9"); topP != "" {
-		if topPValue, err := strconv.ParseFloat(topP, 64); err == nil {
-			handlers.DefaultOllamaConfig.TopP = topPValue
-		}
+	// Índices de busca textual (pg_trgm/unaccent)
+	if err := handlers.SetupPlayerSearch(db); err != nil {
+		log.Printf("Aviso: Erro ao preparar busca de jogadores (usando busca sem índices): %v", err)
 	}
 }
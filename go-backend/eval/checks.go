@@ -0,0 +1,239 @@
+package eval
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+)
+
+// Regras aplicadas a cada análise
+const (
+	CheckValidJSON = "valid_json" // JSON no schema já na primeira resposta
+	CheckStats     = "stats"      // cita as estatísticas principais da posição
+	CheckPosition  = "position"   // trata o jogador pela posição dele
+	CheckLanguage  = "language"   // escrita no idioma pedido
+	CheckLength    = "length"     // resumo dentro dos limites de palavras
+)
+
+// CheckNames lista as regras na ordem do relatório
+var CheckNames = []string{CheckValidJSON, CheckStats, CheckPosition, CheckLanguage, CheckLength}
+
+// defaultSeasonGames é a temporada estimada usada nas médias dos jogadores sem
+// atuações, a mesma da análise da API
+const defaultSeasonGames = 30
+
+// positionTerms são as palavras que identificam cada posição em cada idioma
+var positionTerms = map[string]map[string][]string{
+	"atacante": {
+		i18n.PT: {"atacante", "centroavante", "ponta", "avançado", "artilheiro"},
+		i18n.EN: {"forward", "striker", "winger", "attacker", "centre-forward", "center-forward"},
+		i18n.ES: {"delantero", "atacante", "extremo", "ariete", "goleador"},
+	},
+	"meio-campo": {
+		i18n.PT: {"meio-campo", "meio-campista", "meia", "volante", "meio-campistas"},
+		i18n.EN: {"midfielder", "midfield", "playmaker"},
+		i18n.ES: {"centrocampista", "mediocampista", "volante", "mediocentro", "medio"},
+	},
+	"zagueiro": {
+		i18n.PT: {"zagueiro", "defensor", "beque", "zaga"},
+		i18n.EN: {"defender", "centre-back", "center-back", "defence", "defense"},
+		i18n.ES: {"defensa", "defensor", "central", "zaguero"},
+	},
+	"goleiro": {
+		i18n.PT: {"goleiro", "arqueiro"},
+		i18n.EN: {"goalkeeper", "keeper", "goalie"},
+		i18n.ES: {"portero", "arquero", "guardameta"},
+	},
+}
+
+// languageWords são palavras frequentes e exclusivas de cada idioma, usadas
+// para identificar em que idioma a análise foi escrita
+var languageWords = map[string][]string{
+	i18n.PT: {"não", "com", "uma", "um", "seu", "sua", "ele", "jogador", "também", "mais", "pelo", "pela", "muito", "ao", "são", "do", "da", "dos", "das", "o", "os", "e", "em", "na", "bom", "boa", "pode", "ótimo", "jogo", "gols"},
+	i18n.EN: {"the", "and", "with", "his", "is", "of", "player", "to", "in", "an", "he", "has", "good", "strong", "this", "for", "which", "season", "goals", "game", "can"},
+	i18n.ES: {"el", "los", "del", "jugador", "muy", "y", "un", "una", "su", "sus", "al", "es", "por", "la", "las", "lo", "buen", "buena", "puede", "partido", "goles", "también", "en", "con"},
+}
+
+// numberPattern encontra números inteiros e decimais, com ponto ou vírgula
+var numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)?`)
+
+// runChecks aplica as regras à análise; calls é o número de chamadas ao modelo
+func runChecks(player models.Player, lang string, report models.ScoutReport, calls int, options Options) []Check {
+	text := reportText(report)
+	return []Check{
+		checkValidJSON(calls),
+		checkStats(player, text),
+		checkPosition(player, lang, text),
+		checkLanguage(lang, text),
+		checkLength(report.Summary, options),
+	}
+}
+
+// failedChecks reprova todas as regras de uma análise que não foi gerada
+func failedChecks(err error) []Check {
+	checks := make([]Check, 0, len(CheckNames))
+	for _, name := range CheckNames {
+		checks = append(checks, Check{Name: name, Detail: "sem análise: " + err.Error()})
+	}
+	return checks
+}
+
+// score é a fração das regras aprovadas
+func score(checks []Check) float64 {
+	if len(checks) == 0 {
+		return 0
+	}
+	passed := 0
+	for _, check := range checks {
+		if check.Passed {
+			passed++
+		}
+	}
+	return round(float64(passed) / float64(len(checks)))
+}
+
+// reportText junta os textos da análise
+func reportText(report models.ScoutReport) string {
+	parts := []string{report.Summary}
+	parts = append(parts, report.Strengths...)
+	parts = append(parts, report.Weaknesses...)
+	parts = append(parts, report.RiskFlags...)
+	return strings.Join(parts, "\n")
+}
+
+// checkValidJSON aprova a análise que veio no schema sem pedido de correção
+func checkValidJSON(calls int) Check {
+	check := Check{Name: CheckValidJSON, Passed: calls <= 1}
+	if !check.Passed {
+		check.Detail = fmt.Sprintf("JSON válido só depois de %d correção(ões)", calls-1)
+	}
+	return check
+}
+
+// checkStats confere se a análise cita a estatística principal da posição,
+// pelo total ou pela média por jogo
+func checkStats(player models.Player, text string) Check {
+	stats := map[string]int{"goals": player.Goals, "tackles": player.Tackles, "passes": player.Passes}
+	var required []string
+	switch normalizePosition(player.Position) {
+	case "atacante":
+		required = []string{"goals"}
+	case "meio-campo":
+		required = []string{"passes"}
+	case "zagueiro", "goleiro":
+		required = []string{"tackles"}
+	default:
+		required = []string{"goals", "tackles", "passes"}
+	}
+
+	numbers := map[string]bool{}
+	for _, number := range numberPattern.FindAllString(text, -1) {
+		numbers[strings.ReplaceAll(number, ",", ".")] = true
+	}
+
+	var missing []string
+	for _, name := range required {
+		total := stats[name]
+		perGame := float64(total) / defaultSeasonGames
+		if numbers[fmt.Sprint(total)] || numbers[fmt.Sprintf("%.2f", perGame)] || numbers[fmt.Sprintf("%.1f", perGame)] {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s (%d)", name, total))
+	}
+
+	check := Check{Name: CheckStats, Passed: len(missing) == 0}
+	if !check.Passed {
+		check.Detail = "não cita " + strings.Join(missing, ", ")
+	}
+	return check
+}
+
+// checkPosition confere se a análise trata o jogador pela posição dele
+func checkPosition(player models.Player, lang, text string) Check {
+	terms, ok := positionTerms[normalizePosition(player.Position)]
+	if !ok {
+		return Check{Name: CheckPosition, Passed: true, Detail: "posição sem regra: " + player.Position}
+	}
+
+	words := wordSet(text)
+	for _, term := range terms[lang] {
+		if words[term] {
+			return Check{Name: CheckPosition, Passed: true}
+		}
+	}
+	return Check{Name: CheckPosition, Detail: fmt.Sprintf("não cita a posição (%s)", strings.Join(terms[lang], ", "))}
+}
+
+// checkLanguage confere se o idioma mais provável do texto é o pedido
+func checkLanguage(lang, text string) Check {
+	detected := detectLanguage(text)
+	check := Check{Name: CheckLanguage, Passed: detected == lang}
+	if !check.Passed {
+		if detected == "" {
+			detected = "desconhecido"
+		}
+		check.Detail = fmt.Sprintf("idioma detectado: %s", detected)
+	}
+	return check
+}
+
+// checkLength confere o número de palavras do resumo
+func checkLength(summary string, options Options) Check {
+	count := len(words(summary))
+	check := Check{Name: CheckLength, Passed: count >= options.MinWords && (options.MaxWords <= 0 || count <= options.MaxWords)}
+	if !check.Passed {
+		check.Detail = fmt.Sprintf("%d palavras (esperado de %d a %d)", count, options.MinWords, options.MaxWords)
+	}
+	return check
+}
+
+// detectLanguage conta as palavras típicas de cada idioma; vazio se nenhuma aparece
+func detectLanguage(text string) string {
+	counts := map[string]int{}
+	for _, word := range words(text) {
+		for _, lang := range i18n.Supported {
+			for _, common := range languageWords[lang] {
+				if word == common {
+					counts[lang]++
+				}
+			}
+		}
+	}
+
+	detected, best := "", 0
+	for _, lang := range i18n.Supported {
+		if counts[lang] > best {
+			detected, best = lang, counts[lang]
+		}
+	}
+	return detected
+}
+
+// normalizePosition agrupa as grafias da mesma posição
+func normalizePosition(position string) string {
+	position = strings.ToLower(strings.TrimSpace(position))
+	if position == "meio-campista" {
+		return "meio-campo"
+	}
+	return position
+}
+
+// words separa o texto em palavras minúsculas; hífens ficam na palavra
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+}
+
+// wordSet retorna as palavras do texto, sem repetições
+func wordSet(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words(text) {
+		set[word] = true
+	}
+	return set
+}
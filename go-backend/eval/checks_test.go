@@ -0,0 +1,76 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	assert.Equal(t, i18n.PT, detectLanguage("O jogador tem boa finalização e não desiste das jogadas."))
+	assert.Equal(t, i18n.EN, detectLanguage("The player has good finishing and is strong in the air."))
+	assert.Equal(t, i18n.ES, detectLanguage("El jugador tiene buena definición y es muy fuerte en el juego aéreo."))
+	assert.Equal(t, "", detectLanguage("18 / 0.60"))
+}
+
+func TestCheckStats(t *testing.T) {
+	defender := models.Player{Position: "Zagueiro", Goals: 2, Tackles: 96, Passes: 780}
+
+	assert.True(t, checkStats(defender, "Realizou 96 desarmes.").Passed)
+	// A média por jogo também conta, com vírgula ou ponto
+	assert.True(t, checkStats(defender, "Média de 3,2 desarmes por jogo.").Passed)
+
+	check := checkStats(defender, "Marcou 2 gols e deu 780 passes.")
+	assert.False(t, check.Passed)
+	assert.Equal(t, "não cita tackles (96)", check.Detail)
+}
+
+func TestCheckPosition(t *testing.T) {
+	midfielder := models.Player{Position: "Meio-campista"}
+
+	assert.True(t, checkPosition(midfielder, i18n.PT, "Um meio-campo de muita visão.").Passed)
+	assert.True(t, checkPosition(midfielder, i18n.EN, "A box-to-box midfielder.").Passed)
+	assert.False(t, checkPosition(midfielder, i18n.ES, "Un delantero rápido.").Passed)
+	// Posições sem regra não reprovam a análise
+	assert.True(t, checkPosition(models.Player{Position: "Lateral"}, i18n.PT, "").Passed)
+}
+
+func TestCheckLength(t *testing.T) {
+	options := Options{MinWords: 3, MaxWords: 5}
+	assert.True(t, checkLength("um dois três", options).Passed)
+	assert.False(t, checkLength("um dois", options).Passed)
+	assert.False(t, checkLength("um dois três quatro cinco seis", options).Passed)
+}
+
+func TestLoadCases(t *testing.T) {
+	cases, err := LoadCases("")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cases)
+	langs := map[string]bool{}
+	for _, evalCase := range cases {
+		lang, ok := i18n.Parse(evalCase.Lang)
+		if !ok {
+			lang = i18n.Default
+		}
+		langs[lang] = true
+	}
+	assert.Len(t, langs, len(i18n.Supported))
+
+	dir := t.TempDir()
+	invalid := map[string]string{
+		"vazio.json":    `[]`,
+		"sem_nome.json": `[{"player": {"name": "A"}}]`,
+		"repetido.json": `[{"name": "a"}, {"name": "a"}]`,
+		"idioma.json":   `[{"name": "a", "lang": "fr"}]`,
+	}
+	for file, content := range invalid {
+		path := filepath.Join(dir, file)
+		os.WriteFile(path, []byte(content), 0o644)
+		_, err := LoadCases(path)
+		assert.Error(t, err, file)
+	}
+}
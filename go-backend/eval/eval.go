@@ -0,0 +1,279 @@
+// Package eval avalia offline as análises geradas pelo LLM: roda um conjunto
+// fixo de jogadores por uma configuração de modelo e prompts, pontua as
+// respostas com regras (e, opcionalmente, com outro modelo como juiz) e
+// compara duas configurações
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mvcbotelho/scout-ai/handlers"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+	"golang.org/x/sync/errgroup"
+)
+
+// Config descreve o modelo e os prompts avaliados; é lida de um arquivo JSON
+type Config struct {
+	Name        string   `json:"name"`
+	Provider    string   `json:"provider"` // ollama, openai ou fake
+	BaseURL     string   `json:"base_url"`
+	Model       string   `json:"model"`
+	APIKey      string   `json:"api_key"` // aceita variáveis de ambiente, como ${LLM_API_KEY}
+	Temperature *float64 `json:"temperature"`
+	TopP        *float64 `json:"top_p"`
+	Timeout     string   `json:"timeout"` // por chamada, como 60s
+
+	// PromptsDir substitui os templates embutidos, como PROMPTS_DIR na API
+	PromptsDir string `json:"prompts_dir"`
+}
+
+// FakeConfig é a configuração usada quando o arquivo informado é "fake"
+var FakeConfig = Config{Name: llm.ProviderFake, Provider: llm.ProviderFake}
+
+// LoadConfig lê a configuração de um arquivo JSON; sem nome, usa o do arquivo
+func LoadConfig(path string) (Config, error) {
+	if path == llm.ProviderFake {
+		return FakeConfig, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("erro ao ler a configuração: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return Config{}, fmt.Errorf("configuração inválida (%s): %w", path, err)
+	}
+	if config.Name == "" {
+		config.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	config.APIKey = os.ExpandEnv(config.APIKey)
+	return config, nil
+}
+
+// Open cria o provedor e carrega os templates da configuração
+func (c Config) Open() (llm.Provider, *prompts.Registry, error) {
+	settings := llm.DefaultConfig
+	if c.Provider != "" {
+		settings.Provider = c.Provider
+	}
+	if c.BaseURL != "" || settings.Provider != llm.ProviderOllama {
+		settings.BaseURL = c.BaseURL
+	}
+	if c.Model != "" {
+		settings.Model = c.Model
+	}
+	settings.APIKey = c.APIKey
+	if c.Temperature != nil {
+		settings.Temperature = *c.Temperature
+	}
+	if c.TopP != nil {
+		settings.TopP = *c.TopP
+	}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("timeout inválido em %s: %w", c.Name, err)
+		}
+		settings.Timeout = timeout
+	}
+
+	provider, err := llm.New(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+	registry, err := prompts.New(c.PromptsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, registry, nil
+}
+
+// Case é um jogador do conjunto de avaliação, analisado no idioma Lang
+type Case struct {
+	Name   string        `json:"name"`
+	Lang   string        `json:"lang"` // padrão pt-BR
+	Player models.Player `json:"player"`
+}
+
+// Options controla a execução e as regras da avaliação
+type Options struct {
+	// Workers é o número de jogadores analisados ao mesmo tempo
+	Workers int
+	// MinWords e MaxWords limitam o tamanho do resumo (summary)
+	MinWords int
+	MaxWords int
+	// Judge, quando informado, dá uma nota de 1 a 5 a cada análise
+	Judge llm.Provider
+}
+
+// DefaultOptions são as opções padrão do comando eval
+var DefaultOptions = Options{Workers: 4, MinWords: 40, MaxWords: 300}
+
+// Check é o resultado de uma regra em uma análise
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// CaseResult é a análise de um jogador e a sua avaliação
+type CaseResult struct {
+	Case          string              `json:"case"`
+	Lang          string              `json:"lang"`
+	PromptVersion string              `json:"prompt_version,omitempty"`
+	Report        *models.ScoutReport `json:"report,omitempty"`
+	Error         string              `json:"error,omitempty"`
+	// Calls conta as chamadas ao modelo, inclusive os pedidos de correção do JSON
+	Calls     int        `json:"calls"`
+	LatencyMS int64      `json:"latency_ms"`
+	Checks    []Check    `json:"checks"`
+	Score     float64    `json:"score"` // fração das regras aprovadas (0-1)
+	Judge     *Judgement `json:"judge,omitempty"`
+}
+
+// Summary resume a avaliação de uma configuração
+type Summary struct {
+	Cases  int `json:"cases"`
+	Errors int `json:"errors"`
+	// Repairs conta as análises que só vieram em JSON válido depois de correção
+	Repairs int     `json:"repairs"`
+	Score   float64 `json:"score"`
+	// Checks é a taxa de aprovação de cada regra
+	Checks     map[string]float64 `json:"checks"`
+	JudgeScore float64            `json:"judge_score,omitempty"` // média de 1 a 5
+	Judged     int                `json:"judged"`
+	LatencyMS  int64              `json:"latency_ms"` // média por jogador
+}
+
+// Result é a avaliação de uma configuração
+type Result struct {
+	Config   string       `json:"config"`
+	Settings llm.Settings `json:"settings"`
+	Cases    []CaseResult `json:"cases"`
+	Summary  Summary      `json:"summary"`
+}
+
+// Run analisa os casos com o provedor e os templates de registry e avalia as
+// respostas. A ordem dos resultados é a dos casos
+func Run(ctx context.Context, name string, provider llm.Provider, registry *prompts.Registry, cases []Case, options Options) Result {
+	results := make([]CaseResult, len(cases))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(options.Workers, 1))
+	for i, evalCase := range cases {
+		group.Go(func() error {
+			results[i] = runCase(groupCtx, provider, registry, evalCase, options)
+			return nil
+		})
+	}
+	group.Wait()
+
+	return Result{
+		Config:   name,
+		Settings: llm.Describe(provider),
+		Cases:    results,
+		Summary:  summarize(results),
+	}
+}
+
+// runCase gera a análise de um jogador e aplica as regras e o juiz
+func runCase(ctx context.Context, provider llm.Provider, registry *prompts.Registry, evalCase Case, options Options) CaseResult {
+	lang := i18n.Default
+	if evalCase.Lang != "" {
+		if parsed, ok := i18n.Parse(evalCase.Lang); ok {
+			lang = parsed
+		}
+	}
+
+	counter := &callCounter{Provider: provider}
+	start := time.Now()
+	report, prompt, err := handlers.GenerateScoutReport(ctx, counter, registry, evalCase.Player, lang)
+	result := CaseResult{
+		Case:          evalCase.Name,
+		Lang:          lang,
+		PromptVersion: prompt.Version,
+		Calls:         int(counter.calls.Load()),
+		LatencyMS:     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Checks = failedChecks(err)
+		return result
+	}
+
+	result.Report = &report
+	result.Checks = runChecks(evalCase.Player, lang, report, result.Calls, options)
+	result.Score = score(result.Checks)
+	if options.Judge != nil {
+		result.Judge = judge(ctx, options.Judge, evalCase.Player, lang, report)
+	}
+	return result
+}
+
+// summarize calcula as médias da avaliação
+func summarize(results []CaseResult) Summary {
+	summary := Summary{Cases: len(results), Checks: map[string]float64{}}
+	if len(results) == 0 {
+		return summary
+	}
+
+	var judgeTotal int
+	var latency int64
+	for _, result := range results {
+		if result.Error != "" {
+			summary.Errors++
+		} else if result.Calls > 1 {
+			summary.Repairs++
+		}
+		summary.Score += result.Score
+		latency += result.LatencyMS
+		for _, check := range result.Checks {
+			if check.Passed {
+				summary.Checks[check.Name]++
+			}
+		}
+		if result.Judge != nil && result.Judge.Error == "" {
+			summary.Judged++
+			judgeTotal += result.Judge.Score
+		}
+	}
+
+	cases := float64(len(results))
+	summary.Score = round(summary.Score / cases)
+	summary.LatencyMS = latency / int64(len(results))
+	for _, name := range CheckNames {
+		summary.Checks[name] = round(summary.Checks[name] / cases)
+	}
+	if summary.Judged > 0 {
+		summary.JudgeScore = round(float64(judgeTotal) / float64(summary.Judged))
+	}
+	return summary
+}
+
+// callCounter conta as chamadas feitas ao provedor
+type callCounter struct {
+	llm.Provider
+	calls atomic.Int32
+}
+
+// Generate conta a chamada e a repassa ao provedor
+func (c *callCounter) Generate(ctx context.Context, req llm.Request) (string, error) {
+	c.calls.Add(1)
+	return c.Provider.Generate(ctx, req)
+}
+
+// round arredonda para duas casas decimais
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
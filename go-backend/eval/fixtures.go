@@ -0,0 +1,52 @@
+package eval
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+)
+
+// defaultFixtures é o conjunto padrão de jogadores avaliados
+//
+//go:embed fixtures/players.json
+var defaultFixtures []byte
+
+// LoadCases lê os casos de um arquivo JSON; path vazio usa o conjunto padrão
+func LoadCases(path string) ([]Case, error) {
+	content := defaultFixtures
+	if path != "" {
+		var err error
+		if content, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("erro ao ler os casos: %w", err)
+		}
+	}
+
+	var cases []Case
+	if err := json.Unmarshal(content, &cases); err != nil {
+		return nil, fmt.Errorf("casos inválidos: %w", err)
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("nenhum caso de avaliação")
+	}
+
+	// Os nomes identificam os casos na comparação entre configurações
+	seen := map[string]bool{}
+	for i, evalCase := range cases {
+		if evalCase.Name == "" {
+			return nil, fmt.Errorf("caso %d sem nome", i+1)
+		}
+		if seen[evalCase.Name] {
+			return nil, fmt.Errorf("caso repetido: %s", evalCase.Name)
+		}
+		seen[evalCase.Name] = true
+		if evalCase.Lang != "" {
+			if _, ok := i18n.Parse(evalCase.Lang); !ok {
+				return nil, fmt.Errorf("caso %s: idioma não suportado: %s", evalCase.Name, evalCase.Lang)
+			}
+		}
+	}
+	return cases, nil
+}
@@ -0,0 +1,91 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+)
+
+// Judgement é a nota dada pelo modelo juiz a uma análise
+type Judgement struct {
+	Score  int    `json:"score"` // 1-5
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// judgeSchema é o formato da resposta pedido ao juiz
+var judgeSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "score": {"type": "integer", "minimum": 1, "maximum": 5},
+    "reason": {"type": "string"}
+  },
+  "required": ["score", "reason"]
+}`)
+
+// judgePrompt pede ao juiz a nota da análise, com os dados do jogador para
+// conferir se o texto é fiel a eles
+const judgePrompt = `Você avalia relatórios de scouting de futebol escritos por outro modelo.
+
+DADOS DO JOGADOR:
+- Nome: %s
+- Idade: %d anos
+- Posição: %s
+- Time: %s
+- Gols: %d
+- Tackles: %d
+- Passes: %d
+
+IDIOMA PEDIDO: %s
+
+RELATÓRIO AVALIADO (JSON):
+%s
+
+Dê uma nota de 1 (inútil) a 5 (excelente) considerando: fidelidade aos dados (nenhum número ou fato inventado), coerência com a posição e a idade, utilidade para a decisão de contratação, clareza e uso do idioma pedido.
+
+Responda apenas com um objeto JSON com os campos "score" (inteiro de 1 a 5) e "reason" (uma frase justificando a nota).`
+
+// judge pede ao modelo juiz a nota da análise; falhas do juiz ficam em Error
+// e não entram na média
+func judge(ctx context.Context, provider llm.Provider, player models.Player, lang string, report models.ScoutReport) *Judgement {
+	encoded, _ := json.MarshalIndent(report, "", "  ")
+	prompt := fmt.Sprintf(judgePrompt, player.Name, player.Age, player.Position, player.Team,
+		player.Goals, player.Tackles, player.Passes, lang, encoded)
+
+	response, err := provider.Generate(ctx, llm.Request{
+		Messages: []llm.Message{{Role: llm.RoleUser, Content: prompt}},
+		Format:   judgeSchema,
+	})
+	if err != nil {
+		return &Judgement{Error: err.Error()}
+	}
+
+	judgement, err := parseJudgement(response)
+	if err != nil {
+		return &Judgement{Error: err.Error()}
+	}
+	return &judgement
+}
+
+// parseJudgement lê a nota do juiz; aceita o JSON cercado de texto
+func parseJudgement(response string) (Judgement, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return Judgement{}, fmt.Errorf("%w: resposta do juiz sem objeto JSON", llm.ErrInvalidOutput)
+	}
+
+	var judgement Judgement
+	if err := json.Unmarshal([]byte(response[start:end+1]), &judgement); err != nil {
+		return Judgement{}, fmt.Errorf("%w: JSON inválido do juiz: %v", llm.ErrInvalidOutput, err)
+	}
+	if judgement.Score < 1 || judgement.Score > 5 {
+		return Judgement{}, fmt.Errorf("%w: nota do juiz fora de 1 a 5: %d", llm.ErrInvalidOutput, judgement.Score)
+	}
+	judgement.Error = ""
+	return judgement, nil
+}
@@ -0,0 +1,301 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Resultados da comparação entre a configuração candidata e a de referência
+const (
+	VerdictBetter     = "better"
+	VerdictWorse      = "worse"
+	VerdictEquivalent = "equivalent"
+)
+
+// ScoreTolerance é a diferença de pontuação abaixo da qual as configurações
+// são consideradas equivalentes pelas regras; JudgeTolerance vale para a nota do juiz
+const (
+	ScoreTolerance = 0.02
+	JudgeTolerance = 0.25
+)
+
+// verdictText descreve os resultados no relatório
+var verdictText = map[string]string{
+	VerdictBetter:     "melhor que",
+	VerdictWorse:      "pior que",
+	VerdictEquivalent: "equivalente a",
+}
+
+// CaseDelta compara a avaliação de um caso nas duas configurações
+type CaseDelta struct {
+	Case           string  `json:"case"`
+	Lang           string  `json:"lang"`
+	Baseline       float64 `json:"baseline"`
+	Candidate      float64 `json:"candidate"`
+	Delta          float64 `json:"delta"`
+	BaselineJudge  int     `json:"baseline_judge,omitempty"`
+	CandidateJudge int     `json:"candidate_judge,omitempty"`
+	// Failed são as regras em que a candidata falhou
+	Failed []string `json:"failed,omitempty"`
+}
+
+// Comparison compara a configuração candidata com a de referência (baseline)
+type Comparison struct {
+	Baseline   Result  `json:"baseline"`
+	Candidate  Result  `json:"candidate"`
+	ScoreDelta float64 `json:"score_delta"`
+	JudgeDelta float64 `json:"judge_delta,omitempty"`
+	// Checks é a diferença na taxa de aprovação de cada regra
+	Checks  map[string]float64 `json:"checks"`
+	Cases   []CaseDelta        `json:"cases"`
+	Verdict string             `json:"verdict"`
+}
+
+// Compare compara as avaliações caso a caso, pelo nome; casos presentes em
+// apenas uma delas são ignorados
+func Compare(baseline, candidate Result) Comparison {
+	comparison := Comparison{
+		Baseline:   baseline,
+		Candidate:  candidate,
+		ScoreDelta: round(candidate.Summary.Score - baseline.Summary.Score),
+		Checks:     map[string]float64{},
+		Verdict:    VerdictEquivalent,
+	}
+	for _, name := range CheckNames {
+		comparison.Checks[name] = round(candidate.Summary.Checks[name] - baseline.Summary.Checks[name])
+	}
+	judged := baseline.Summary.Judged > 0 && candidate.Summary.Judged > 0
+	if judged {
+		comparison.JudgeDelta = round(candidate.Summary.JudgeScore - baseline.Summary.JudgeScore)
+	}
+
+	baselineCases := map[string]CaseResult{}
+	for _, result := range baseline.Cases {
+		baselineCases[result.Case] = result
+	}
+	for _, result := range candidate.Cases {
+		reference, ok := baselineCases[result.Case]
+		if !ok {
+			continue
+		}
+		delta := CaseDelta{
+			Case:           result.Case,
+			Lang:           result.Lang,
+			Baseline:       reference.Score,
+			Candidate:      result.Score,
+			Delta:          round(result.Score - reference.Score),
+			BaselineJudge:  judgeScore(reference.Judge),
+			CandidateJudge: judgeScore(result.Judge),
+		}
+		for _, check := range result.Checks {
+			if !check.Passed {
+				delta.Failed = append(delta.Failed, check.Name)
+			}
+		}
+		comparison.Cases = append(comparison.Cases, delta)
+	}
+
+	// As regras decidem; com pontuações equivalentes, decide o juiz
+	switch {
+	case comparison.ScoreDelta > ScoreTolerance:
+		comparison.Verdict = VerdictBetter
+	case comparison.ScoreDelta < -ScoreTolerance:
+		comparison.Verdict = VerdictWorse
+	case judged && comparison.JudgeDelta >= JudgeTolerance:
+		comparison.Verdict = VerdictBetter
+	case judged && comparison.JudgeDelta <= -JudgeTolerance:
+		comparison.Verdict = VerdictWorse
+	}
+	return comparison
+}
+
+// WriteResult escreve em Markdown o relatório de uma configuração
+func WriteResult(w io.Writer, result Result) {
+	fmt.Fprintf(w, "# Avaliação offline: %s\n\n", result.Config)
+	fmt.Fprintf(w, "Modelo: %s\n\n", describeSettings(result))
+
+	fmt.Fprintln(w, "| Métrica | Valor |")
+	fmt.Fprintln(w, "|---------|-------|")
+	for _, row := range summaryRows(result.Summary) {
+		fmt.Fprintf(w, "| %s | %s |\n", row.name, row.format(result.Summary))
+	}
+
+	fmt.Fprintln(w, "\n## Casos")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Caso | Idioma | Pontuação | Juiz | Latência | Falhas |")
+	fmt.Fprintln(w, "|------|--------|-----------|------|----------|--------|")
+	for _, result := range result.Cases {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %d ms | %s |\n", result.Case, result.Lang, percent(result.Score),
+			judgeText(result.Judge), result.LatencyMS, failures(result))
+	}
+}
+
+// WriteComparison escreve em Markdown o relatório da comparação
+func WriteComparison(w io.Writer, comparison Comparison) {
+	baseline, candidate := comparison.Baseline, comparison.Candidate
+	fmt.Fprintf(w, "# Avaliação offline: %s × %s\n\n", baseline.Config, candidate.Config)
+	fmt.Fprintf(w, "- **%s**: %s\n", baseline.Config, describeSettings(baseline))
+	fmt.Fprintf(w, "- **%s**: %s\n\n", candidate.Config, describeSettings(candidate))
+	fmt.Fprintf(w, "**Resultado:** %s é %s %s", candidate.Config, verdictText[comparison.Verdict], baseline.Config)
+	if comparison.Verdict == VerdictEquivalent {
+		fmt.Fprintf(w, "\n\n")
+	} else {
+		fmt.Fprintf(w, " (pontuação %s)\n\n", signedPoints(comparison.ScoreDelta))
+	}
+
+	fmt.Fprintf(w, "| Métrica | %s | %s | Diferença |\n", baseline.Config, candidate.Config)
+	fmt.Fprintln(w, "|---------|------|------|-----------|")
+	for _, row := range summaryRows(baseline.Summary, candidate.Summary) {
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", row.name, row.format(baseline.Summary), row.format(candidate.Summary),
+			row.delta(baseline.Summary, candidate.Summary))
+	}
+
+	fmt.Fprintln(w, "\n## Casos")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "| Caso | Idioma | %s | %s | Diferença | Falhas de %s |\n", baseline.Config, candidate.Config, candidate.Config)
+	fmt.Fprintln(w, "|------|--------|------|------|-----------|--------|")
+	cases := append([]CaseDelta(nil), comparison.Cases...)
+	// As maiores pioras primeiro
+	sort.SliceStable(cases, func(i, j int) bool { return cases[i].Delta < cases[j].Delta })
+	for _, delta := range cases {
+		failed := strings.Join(delta.Failed, ", ")
+		if failed == "" {
+			failed = "-"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n", delta.Case, delta.Lang,
+			withJudge(delta.Baseline, delta.BaselineJudge), withJudge(delta.Candidate, delta.CandidateJudge),
+			signedPoints(delta.Delta), failed)
+	}
+}
+
+// summaryRow é uma linha da tabela de resumo
+type summaryRow struct {
+	name   string
+	format func(Summary) string
+	delta  func(baseline, candidate Summary) string
+}
+
+// summaryRows são as linhas da tabela de resumo; a nota do juiz só aparece
+// quando todas as avaliações a têm
+func summaryRows(summaries ...Summary) []summaryRow {
+	rows := []summaryRow{
+		{"Casos", func(s Summary) string { return fmt.Sprint(s.Cases) }, func(b, c Summary) string { return signedInt(c.Cases - b.Cases) }},
+		{"Erros", func(s Summary) string { return fmt.Sprint(s.Errors) }, func(b, c Summary) string { return signedInt(c.Errors - b.Errors) }},
+		{"Correções de JSON", func(s Summary) string { return fmt.Sprint(s.Repairs) }, func(b, c Summary) string { return signedInt(c.Repairs - b.Repairs) }},
+		{"Pontuação das regras", func(s Summary) string { return percent(s.Score) }, func(b, c Summary) string { return signedPoints(c.Score - b.Score) }},
+	}
+	for _, name := range CheckNames {
+		rows = append(rows, summaryRow{
+			"`" + name + "`",
+			func(s Summary) string { return percent(s.Checks[name]) },
+			func(b, c Summary) string { return signedPoints(c.Checks[name] - b.Checks[name]) },
+		})
+	}
+
+	judged := true
+	for _, summary := range summaries {
+		judged = judged && summary.Judged > 0
+	}
+	if judged {
+		rows = append(rows, summaryRow{"Nota do juiz (1-5)",
+			func(s Summary) string { return fmt.Sprintf("%.2f", s.JudgeScore) },
+			func(b, c Summary) string { return fmt.Sprintf("%+.2f", c.JudgeScore-b.JudgeScore) }})
+	}
+
+	return append(rows, summaryRow{"Latência média",
+		func(s Summary) string { return fmt.Sprintf("%d ms", s.LatencyMS) },
+		func(b, c Summary) string { return fmt.Sprintf("%+d ms", c.LatencyMS-b.LatencyMS) }})
+}
+
+// describeSettings descreve o modelo e as versões de prompt usadas
+func describeSettings(result Result) string {
+	settings := result.Settings
+	description := settings.Provider
+	if settings.Model != "" && settings.Model != settings.Provider {
+		description += "/" + settings.Model
+	}
+	if settings.Temperature != 0 || settings.TopP != 0 {
+		description += fmt.Sprintf(" (temperature %.2f, top_p %.2f)", settings.Temperature, settings.TopP)
+	}
+
+	seen := map[string]bool{}
+	var versions []string
+	for _, result := range result.Cases {
+		if result.PromptVersion != "" && !seen[result.PromptVersion] {
+			seen[result.PromptVersion] = true
+			versions = append(versions, result.PromptVersion)
+		}
+	}
+	if len(versions) > 0 {
+		sort.Strings(versions)
+		description += ", prompt " + strings.Join(versions, ", ")
+	}
+	return description
+}
+
+// failures lista as regras reprovadas do caso, com o motivo
+func failures(result CaseResult) string {
+	if result.Error != "" {
+		return "erro: " + escapeCell(result.Error)
+	}
+	var failed []string
+	for _, check := range result.Checks {
+		if !check.Passed {
+			failed = append(failed, fmt.Sprintf("`%s` (%s)", check.Name, escapeCell(check.Detail)))
+		}
+	}
+	if len(failed) == 0 {
+		return "-"
+	}
+	return strings.Join(failed, "; ")
+}
+
+// judgeScore é a nota do juiz, ou zero sem ela
+func judgeScore(judgement *Judgement) int {
+	if judgement == nil || judgement.Error != "" {
+		return 0
+	}
+	return judgement.Score
+}
+
+// judgeText descreve a nota do juiz no relatório
+func judgeText(judgement *Judgement) string {
+	switch {
+	case judgement == nil:
+		return "-"
+	case judgement.Error != "":
+		return "erro"
+	default:
+		return fmt.Sprintf("%d/5", judgement.Score)
+	}
+}
+
+// withJudge formata a pontuação do caso com a nota do juiz, quando houver
+func withJudge(score float64, judge int) string {
+	if judge == 0 {
+		return percent(score)
+	}
+	return fmt.Sprintf("%s (juiz %d/5)", percent(score), judge)
+}
+
+// percent formata uma fração como porcentagem
+func percent(value float64) string {
+	return fmt.Sprintf("%.0f%%", value*100)
+}
+
+// signedPoints formata a diferença entre frações em pontos percentuais
+func signedPoints(delta float64) string {
+	return fmt.Sprintf("%+.0f pp", delta*100)
+}
+
+// signedInt formata a diferença entre contagens
+func signedInt(delta int) string {
+	return fmt.Sprintf("%+d", delta)
+}
+
+// escapeCell evita que o texto quebre a tabela Markdown
+func escapeCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adminTokenHeader é o header com o token das operações administrativas
+const adminTokenHeader = "X-Admin-Token"
+
+// AdminConfig configuração do acesso às operações administrativas
+type AdminConfig struct {
+	// Token exigido no header X-Admin-Token; vazio desabilita as operações
+	Token string
+}
+
+// AdminAccess configuração padrão
+var AdminAccess = AdminConfig{}
+
+// requireAdmin valida o token de administrador, respondendo 403 quando as
+// operações administrativas estão desabilitadas e 401 quando o token não confere
+func requireAdmin(c *gin.Context) bool {
+	if AdminAccess.Token == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Operações administrativas desabilitadas"})
+		return false
+	}
+
+	token := c.GetHeader(adminTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(AdminAccess.Token)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token de administrador inválido"})
+		return false
+	}
+	return true
+}
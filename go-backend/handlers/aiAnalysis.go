@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+)
+
+// errNoProvider indica que nenhum provedor de LLM foi configurado
+var errNoProvider = errors.New("nenhum provedor de LLM configurado")
+
+// AIPrompts são os templates dos prompts; main os substitui pelos do diretório
+// configurado, recarregados quando os arquivos mudam
+var AIPrompts = prompts.Default()
+
+// generateAIAnalysis gera a análise estruturada do jogador com o provedor de
+// LLM a partir do prompt renderizado, retornando também o JSON validado. A
+// análise precisa tratar do jogador pedido (ver validateReportSubject)
+func generateAIAnalysis(ctx context.Context, provider llm.Provider, prompt prompts.Prompt, player models.Player, lang string) (models.ScoutReport, string, error) {
+	if provider == nil {
+		return models.ScoutReport{}, "", errNoProvider
+	}
+
+	report, encoded, err := requestScoutReport(ctx, provider, prompt.Text, player, lang)
+	if err != nil {
+		return models.ScoutReport{}, "", fmt.Errorf("erro ao chamar %s: %w", provider.Name(), err)
+	}
+	return report, encoded, nil
+}
+
+// GenerateScoutReport gera a análise estruturada do jogador com o provedor e
+// os templates de registry, sem cache nem fallback para a análise estática.
+// Usada pela avaliação offline de prompts e modelos (comando eval)
+func GenerateScoutReport(ctx context.Context, provider llm.Provider, registry *prompts.Registry, player models.Player, lang string) (models.ScoutReport, prompts.Prompt, error) {
+	prompt, err := renderAnalysisPrompt(registry, calculatePlayerStats(player), lang)
+	if err != nil {
+		return models.ScoutReport{}, prompts.Prompt{}, err
+	}
+
+	report, _, err := generateAIAnalysis(ctx, provider, prompt, player, lang)
+	return report, prompt, err
+}
+
+// analysisPromptData são os dados disponíveis no template player_analysis:
+// o jogador, as estatísticas e os trechos já descritos em texto, no idioma do prompt
+type analysisPromptData struct {
+	PlayerStats
+	Games            string
+	Per90            string
+	PositionAnalysis string
+	TrendAnalysis    string
+}
+
+// createAnalysisPrompt renderiza o prompt da análise individual no idioma lang
+func createAnalysisPrompt(stats PlayerStats, lang string) (prompts.Prompt, error) {
+	return renderAnalysisPrompt(AIPrompts, stats, lang)
+}
+
+// renderAnalysisPrompt renderiza o prompt da análise individual com os templates de registry
+func renderAnalysisPrompt(registry *prompts.Registry, stats PlayerStats, lang string) (prompts.Prompt, error) {
+	// Nome, time e posição vêm do cadastro: entram limpos e delimitados
+	localized := localizedStats(lang, stats)
+	localized.Player, _ = promptPlayer(lang, stats.Player)
+
+	return registry.RenderLocale(prompts.PlayerAnalysis, lang, analysisPromptData{
+		PlayerStats:      localized,
+		Games:            describeGames(stats, lang),
+		Per90:            describePer90(stats, lang),
+		PositionAnalysis: getPositionAnalysis(stats.Player.Position, stats.Player, stats, lang),
+		TrendAnalysis:    describeTrend(stats, lang),
+	})
+}
+
+// describeGames descreve a amostra de jogos usada nas médias
+func describeGames(stats PlayerStats, lang string) string {
+	if stats.Stats.GamesEstimated {
+		return i18n.T(lang, "prompt.games_estimated", stats.Stats.Games)
+	}
+	return i18n.T(lang, "prompt.games", stats.Stats.Games, stats.Stats.MinutesPlayed)
+}
+
+// describePer90 descreve as médias por 90 minutos, quando há minutagem registrada
+func describePer90(stats PlayerStats, lang string) string {
+	if stats.Stats.MinutesPlayed == 0 {
+		return i18n.T(lang, "prompt.per90_unavailable")
+	}
+	return i18n.T(lang, "prompt.per90",
+		stats.Stats.GoalsPer90, stats.Stats.TacklesPer90, stats.Stats.PassesPer90)
+}
+
+// describeTrend monta a seção de tendência ano a ano para análises por temporada
+func describeTrend(stats PlayerStats, lang string) string {
+	if stats.Season == "" {
+		return ""
+	}
+	season, _ := promptField(lang, stats.Season)
+	if stats.Trend == nil {
+		return i18n.T(lang, "prompt.trend_none", season)
+	}
+	from, _ := promptField(lang, stats.Trend.FromSeason)
+	to, _ := promptField(lang, stats.Trend.ToSeason)
+	return i18n.T(lang, "prompt.trend",
+		from, to, stats.Trend.GamesChange,
+		stats.Trend.GoalsPerGameChange, stats.Trend.TacklesPerGameChange,
+		stats.Trend.PassesPerGameChange, stats.Trend.EfficiencyPerGameChange,
+		labelText(lang, stats.Trend.Direction))
+}
+
+// getPositionAnalysis retorna análise específica da posição no idioma lang
+func getPositionAnalysis(position string, player models.Player, stats PlayerStats, lang string) string {
+	switch strings.ToLower(position) {
+	case "atacante":
+		return i18n.T(lang, "position_analysis.forward",
+			stats.Stats.GoalsPerGame, player.Passes,
+			float64(player.Goals)/float64(player.Passes)*100,
+			getPerformanceComparison(player.Goals, 15, lang))
+
+	case "meio-campo", "meio-campista":
+		return i18n.T(lang, "position_analysis.midfielder",
+			stats.Stats.PassesPerGame, player.Tackles, player.Goals,
+			getPassingQuality(player.Passes, lang))
+
+	case "zagueiro":
+		return i18n.T(lang, "position_analysis.defender",
+			stats.Stats.TacklesPerGame, player.Passes, player.Goals,
+			getDefensiveQuality(player.Tackles, lang))
+
+	case "goleiro":
+		return i18n.T(lang, "position_analysis.goalkeeper",
+			player.Tackles, player.Passes,
+			getGoalkeeperQuality(player.Tackles, lang))
+
+	default:
+		return i18n.T(lang, "position_analysis.other")
+	}
+}
+
+// getPerformanceComparison compara performance com padrões
+func getPerformanceComparison(goals, threshold int, lang string) string {
+	if goals >= threshold {
+		return i18n.T(lang, "quality.above_average")
+	} else if goals >= threshold/2 {
+		return i18n.T(lang, "quality.average")
+	} else {
+		return i18n.T(lang, "quality.below_average")
+	}
+}
+
+// getPassingQuality avalia qualidade dos passes
+func getPassingQuality(passes int, lang string) string {
+	if passes > 300 {
+		return i18n.T(lang, "quality.passing_excellent")
+	} else if passes > 200 {
+		return i18n.T(lang, "quality.passing_good")
+	} else if passes > 100 {
+		return i18n.T(lang, "quality.passing_adequate")
+	} else {
+		return i18n.T(lang, "quality.passing_poor")
+	}
+}
+
+// getDefensiveQuality avalia qualidade defensiva
+func getDefensiveQuality(tackles int, lang string) string {
+	if tackles > 100 {
+		return i18n.T(lang, "quality.defending_excellent")
+	} else if tackles > 70 {
+		return i18n.T(lang, "quality.defending_good")
+	} else if tackles > 40 {
+		return i18n.T(lang, "quality.defending_adequate")
+	} else {
+		return i18n.T(lang, "quality.defending_poor")
+	}
+}
+
+// getGoalkeeperQuality avalia qualidade do goleiro
+func getGoalkeeperQuality(tackles int, lang string) string {
+	if tackles > 20 {
+		return i18n.T(lang, "quality.goalkeeping_excellent")
+	} else if tackles > 10 {
+		return i18n.T(lang, "quality.goalkeeping_good")
+	} else {
+		return i18n.T(lang, "quality.goalkeeping_poor")
+	}
+}
+
+// comparativePromptPlayer é um jogador no template comparative_analysis
+type comparativePromptPlayer struct {
+	Number     int
+	Name       string
+	Position   string
+	Team       string
+	Age        int
+	Goals      int
+	Tackles    int
+	Passes     int
+	Efficiency float64
+}
+
+// createComparativePrompt renderiza o prompt da análise comparativa no idioma lang
+func createComparativePrompt(players []models.Player, lang string) (prompts.Prompt, error) {
+	data := struct{ Players []comparativePromptPlayer }{}
+	for i, player := range players {
+		stats := calculatePlayerStats(player)
+		safe, _ := promptPlayer(lang, player)
+		data.Players = append(data.Players, comparativePromptPlayer{
+			Number:     i + 1,
+			Name:       safe.Name,
+			Position:   safe.Position,
+			Team:       safe.Team,
+			Age:        player.Age,
+			Goals:      stats.Player.Goals,
+			Tackles:    stats.Player.Tackles,
+			Passes:     stats.Player.Passes,
+			Efficiency: stats.Stats.Efficiency,
+		})
+	}
+	return AIPrompts.RenderLocale(prompts.ComparativeAnalysis, lang, data)
+}
+
+// generateComparativeAIAnalysis gera análise comparativa com o provedor de LLM;
+// respostas que mostram que o prompt foi desviado são recusadas
+func generateComparativeAIAnalysis(ctx context.Context, provider llm.Provider, prompt prompts.Prompt, players []models.Player) (string, error) {
+	if provider == nil {
+		return "", errNoProvider
+	}
+	text, err := provider.Generate(ctx, llm.Prompt(prompt.Text))
+	if err != nil {
+		return "", err
+	}
+	if looksHijacked(text, players...) {
+		return "", fmt.Errorf("%w: a análise comparativa contém instruções em vez de avaliar os jogadores", llm.ErrInvalidOutput)
+	}
+	return text, nil
+}
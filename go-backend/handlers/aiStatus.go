@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+)
+
+// Situações da análise com IA informadas em ai_status
+const (
+	AIStatusOK            = "ok"
+	AIStatusTimeout       = "timeout"
+	AIStatusUnavailable   = "unavailable"
+	AIStatusModelMissing  = "model_missing"
+	AIStatusInvalidOutput = "invalid_output"
+)
+
+// aiStatusMessages são as chaves das mensagens que descrevem cada situação
+// para quem consome a API
+var aiStatusMessages = map[string]string{
+	AIStatusOK:            "ai_status.ok",
+	AIStatusTimeout:       "ai_status.timeout",
+	AIStatusUnavailable:   "ai_status.unavailable",
+	AIStatusModelMissing:  "ai_status.model_missing",
+	AIStatusInvalidOutput: "ai_status.invalid_output",
+}
+
+// aiStatusHTTPCodes é o status HTTP de cada falha quando ela não é contornada
+var aiStatusHTTPCodes = map[string]int{
+	AIStatusTimeout:       http.StatusGatewayTimeout,
+	AIStatusUnavailable:   http.StatusServiceUnavailable,
+	AIStatusModelMissing:  http.StatusBadGateway,
+	AIStatusInvalidOutput: http.StatusBadGateway,
+}
+
+// AIStatus explica o resultado da chamada ao LLM, inclusive por que a
+// análise estática foi usada no lugar da IA
+type AIStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"` // erro original, para diagnóstico
+}
+
+// newAIStatus classifica o erro do LLM, com a mensagem no idioma lang; nil
+// significa que a IA respondeu
+func newAIStatus(err error, lang string) *AIStatus {
+	status := AIStatusOK
+	switch {
+	case err == nil:
+	case errors.Is(err, llm.ErrTimeout):
+		status = AIStatusTimeout
+	case errors.Is(err, llm.ErrModelMissing):
+		status = AIStatusModelMissing
+	case errors.Is(err, llm.ErrInvalidOutput):
+		status = AIStatusInvalidOutput
+	default:
+		// Circuit breaker aberto, falhas de rede, erros 5xx e provedor ausente
+		status = AIStatusUnavailable
+	}
+
+	result := &AIStatus{Status: status, Message: i18n.T(lang, aiStatusMessages[status])}
+	if err != nil {
+		result.Detail = err.Error()
+	}
+	return result
+}
+
+// AIConfig configura o comportamento das análises quando o LLM falha
+type AIConfig struct {
+	// FallbackOnTimeout usa a análise estática quando o LLM excede o tempo
+	// limite; quando false, a requisição responde 504 Gateway Timeout
+	FallbackOnTimeout bool
+}
+
+// AIBehavior configuração padrão
+var AIBehavior = AIConfig{
+	FallbackOnTimeout: true,
+}
+
+// aiOptions são as opções de IA da requisição: ai=true pede a análise com o
+// LLM, strict_ai=true (que implica ai=true) recusa o fallback estático e o
+// header Cache-Control: no-cache ignora o cache de análises. Lang é o idioma
+// do prompt e dos textos gerados
+type aiOptions struct {
+	Enabled bool
+	Strict  bool
+	NoCache bool
+	Lang    string
+}
+
+// parseAIOptions lê ai e strict_ai da query string e o header Cache-Control;
+// lang é o idioma já escolhido para a requisição (ver requestLang)
+func parseAIOptions(c *gin.Context, lang string) aiOptions {
+	strict := c.Query("strict_ai") == "true" || c.Query("strict_ai") == "1"
+	return aiOptions{
+		Enabled: strict || c.Query("ai") == "true" || c.Query("ai") == "1",
+		Strict:  strict,
+		NoCache: strings.Contains(strings.ToLower(c.GetHeader("Cache-Control")), "no-cache"),
+		Lang:    lang,
+	}
+}
+
+// fatalAIError retorna o erro do LLM quando ele não deve ser contornado com a
+// análise estática: o cliente desconectou (o fim do prazo de um jogador não
+// conta; veja errPlayerTimeout), o modo strict_ai está ativo ou o
+// tempo limite foi excedido com o fallback desabilitado
+func fatalAIError(ctx context.Context, err error, strict bool) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil && !errors.Is(err, errPlayerTimeout) {
+		return ctx.Err()
+	}
+	if strict {
+		return err
+	}
+	if errors.Is(err, llm.ErrTimeout) && !AIBehavior.FallbackOnTimeout {
+		return err
+	}
+	return nil
+}
+
+// respondAIError responde um erro fatal do LLM com o ai_status e o status HTTP
+// da falha (504, 503 ou 502); se o cliente desconectou, apenas interrompe o handler
+func respondAIError(c *gin.Context, err error, lang string) {
+	if c.Request.Context().Err() != nil {
+		c.Abort()
+		return
+	}
+
+	status := newAIStatus(err, lang)
+	c.JSON(aiStatusHTTPCodes[status.Status], gin.H{
+		"error":     status.Message,
+		"ai_status": status,
+	})
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/cache"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+)
+
+// CacheConfig configura o cache dos textos gerados pela IA
+type CacheConfig struct {
+	// Store guarda os textos; nil desabilita o cache
+	Store cache.Store
+	// TTL de cada texto no cache; zero não expira
+	TTL time.Duration
+}
+
+// AICache configuração padrão: sem store, o cache fica desabilitado
+var AICache = CacheConfig{
+	TTL: 24 * time.Hour,
+}
+
+// Valores do header X-Cache
+const (
+	cacheHit  = "HIT"
+	cacheMiss = "MISS"
+)
+
+// comparativeCacheGroup agrupa as análises comparativas, invalidadas a cada
+// alteração de qualquer jogador
+const comparativeCacheGroup = "comparative"
+
+// playerCacheGroup agrupa as análises de um jogador
+func playerCacheGroup(playerID uint) string {
+	return "player:" + strconv.FormatUint(uint64(playerID), 10)
+}
+
+// analysisCacheKey monta a chave do cache: hash dos dados usados no prompt, da
+// versão e do conteúdo do template e da configuração do provedor (modelo e amostragem)
+func analysisCacheKey(kind string, prompt prompts.Prompt, provider llm.Provider, data interface{}) string {
+	var name string
+	if provider != nil {
+		name = provider.Name()
+	}
+
+	payload, _ := json.Marshal(struct {
+		Kind           string
+		PromptVersion  string
+		PromptChecksum string
+		Provider       string
+		Settings       llm.Settings
+		Data           interface{}
+	}{kind, prompt.Version, prompt.Checksum, name, llm.Describe(provider), data})
+
+	sum := sha256.Sum256(payload)
+	return "analysis:" + kind + ":" + hex.EncodeToString(sum[:])
+}
+
+// playerAnalysisCacheKey é a chave da análise individual do jogador
+func playerAnalysisCacheKey(provider llm.Provider, prompt prompts.Prompt, stats PlayerStats) string {
+	return analysisCacheKey("player", prompt, provider, struct {
+		Player models.PlayerSnapshot
+		Stats  interface{}
+		Season string
+		Trend  *SeasonTrend
+	}{playerSnapshot(stats), stats.Stats, stats.Season, stats.Trend})
+}
+
+// comparativeAnalysisCacheKey é a chave da análise comparativa dos jogadores
+func comparativeAnalysisCacheKey(provider llm.Provider, prompt prompts.Prompt, players []models.Player) string {
+	type cachedPlayer struct {
+		ID     uint
+		Player models.PlayerSnapshot
+	}
+	data := make([]cachedPlayer, len(players))
+	for i, player := range players {
+		data[i] = cachedPlayer{player.ID, playerSnapshot(calculatePlayerStats(player))}
+	}
+	return analysisCacheKey("comparative", prompt, provider, data)
+}
+
+// lookupAIText busca o texto no cache; falhas do store são tratadas como ausência
+func lookupAIText(ctx context.Context, key string, options aiOptions) (string, bool) {
+	if AICache.Store == nil || options.NoCache {
+		return "", false
+	}
+
+	value, ok, err := AICache.Store.Get(ctx, key)
+	if err != nil {
+		log.Printf("Erro ao ler o cache de análises: %v", err)
+		return "", false
+	}
+	return string(value), ok
+}
+
+// storeAIText grava no cache um texto gerado com sucesso
+func storeAIText(ctx context.Context, key, group, text string) {
+	if AICache.Store == nil {
+		return
+	}
+	if err := AICache.Store.Set(ctx, key, group, []byte(text), AICache.TTL); err != nil {
+		log.Printf("Erro ao gravar o cache de análises: %v", err)
+	}
+}
+
+// cachedAIText retorna o texto do cache ou o gera com generate, gravando o
+// resultado quando a geração funciona; hit indica que o texto veio do cache
+func cachedAIText(ctx context.Context, key, group string, options aiOptions, generate func() (string, error)) (text string, hit bool, err error) {
+	if text, ok := lookupAIText(ctx, key, options); ok {
+		return text, true, nil
+	}
+
+	if text, err = generate(); err != nil {
+		return "", false, err
+	}
+	storeAIText(ctx, key, group, text)
+	return text, false, nil
+}
+
+// setCacheHeaders informa no header X-Cache se as análises com IA vieram do
+// cache (HIT apenas quando todas vieram); X-Cache-Hits e X-Cache-Misses trazem
+// as contagens nas respostas com várias análises
+func setCacheHeaders(c *gin.Context, hits, misses int) {
+	if AICache.Store == nil || hits+misses == 0 {
+		return
+	}
+
+	if misses == 0 {
+		c.Header("X-Cache", cacheHit)
+	} else {
+		c.Header("X-Cache", cacheMiss)
+	}
+	if hits+misses > 1 {
+		c.Header("X-Cache-Hits", strconv.Itoa(hits))
+		c.Header("X-Cache-Misses", strconv.Itoa(misses))
+	}
+}
+
+// invalidateAnalysisCache remove do cache as análises dos jogadores alterados e
+// as comparativas, que dependem de todos os jogadores
+func invalidateAnalysisCache(ctx context.Context, playerIDs ...uint) {
+	if AICache.Store == nil {
+		return
+	}
+
+	groups := []string{comparativeCacheGroup}
+	for _, id := range playerIDs {
+		groups = append(groups, playerCacheGroup(id))
+	}
+	for _, group := range groups {
+		if err := AICache.Store.InvalidateGroup(ctx, group); err != nil {
+			log.Printf("Erro ao invalidar o cache de análises (%s): %v", group, err)
+		}
+	}
+}
+
+// playerSnapshot copia os dados do jogador e as estatísticas usadas na análise
+func playerSnapshot(stats PlayerStats) models.PlayerSnapshot {
+	return models.PlayerSnapshot{
+		Name:            stats.Player.Name,
+		Age:             stats.Player.Age,
+		Position:        stats.Player.Position,
+		Team:            stats.Player.Team,
+		Goals:           stats.Player.Goals,
+		Tackles:         stats.Player.Tackles,
+		Passes:          stats.Player.Passes,
+		Version:         stats.Player.Version,
+		Games:           stats.Stats.Games,
+		MinutesPlayed:   stats.Stats.MinutesPlayed,
+		Efficiency:      stats.Stats.Efficiency,
+		PerformanceRank: stats.Stats.PerformanceRank,
+	}
+}
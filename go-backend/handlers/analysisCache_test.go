@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/cache"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzePlayerCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AICache.Store = cache.NewLRU(10)
+	defer func() { AICache.Store = nil }()
+
+	fake := &llm.Fake{Response: reportJSON("João Silva, atacante com excelente finalização")}
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+	router.PATCH("/players/:id", PatchPlayer(db))
+
+	analyze := func(header string) (*httptest.ResponseRecorder, AnalysisResult) {
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+		if header != "" {
+			req.Header.Set("Cache-Control", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var result AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return w, result
+	}
+
+	w, result := analyze("")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.False(t, result.Cached)
+
+	// Jogador inalterado: o texto vem do cache, sem chamar o modelo
+	w, result = analyze("")
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.True(t, result.Cached)
+	assert.True(t, result.AIUsed)
+	assert.Equal(t, "João Silva, atacante com excelente finalização", result.Analysis)
+	assert.Len(t, fake.Requests(), 1)
+
+	// Cache-Control: no-cache ignora o cache
+	w, _ = analyze("no-cache")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Len(t, fake.Requests(), 2)
+
+	// Alterar o jogador invalida o cache
+	req, _ := http.NewRequest("PATCH", "/players/1", bytes.NewBufferString(`{"goals": 16}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Zero(t, AICache.Store.(*cache.LRU).Len())
+
+	w, _ = analyze("")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Len(t, fake.Requests(), 3)
+}
+
+func TestAnalyzeAllPlayersCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 5, Tackles: 20, Passes: 350})
+
+	AICache.Store = cache.NewLRU(10)
+	defer func() { AICache.Store = nil }()
+
+	fake := &llm.Fake{}
+	router := gin.New()
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, fake))
+	router.POST("/players", CreatePlayer(db))
+
+	analyzeAll := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/analyze/players?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := analyzeAll()
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, "3", w.Header().Get("X-Cache-Misses"))
+
+	w = analyzeAll()
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Equal(t, "3", w.Header().Get("X-Cache-Hits"))
+	assert.Len(t, fake.Requests(), 3)
+
+	// Um novo jogador invalida só a comparativa; os demais continuam no cache
+	body, _ := json.Marshal(models.Player{Name: "Carlos Lima", Age: 30, Position: "Zagueiro", Team: "Santos", Tackles: 40})
+	req, _ := http.NewRequest("POST", "/players", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	w = analyzeAll()
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, "2", w.Header().Get("X-Cache-Hits"))
+	assert.Equal(t, "2", w.Header().Get("X-Cache-Misses"))
+}
+
+func TestStreamPlayerAnalysisCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AICache.Store = cache.NewLRU(10)
+	defer func() { AICache.Store = nil }()
+
+	fake := &llm.Fake{Response: reportJSON("João Silva, atacante com excelente finalização")}
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, fake))
+
+	// A análise gerada pelo endpoint comum serve o stream
+	req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest("GET", "/analyze/players/1/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Len(t, fake.Requests(), 1)
+
+	events := readSSEvents(w.Body.String())
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "token", events[1].name)
+		var result AnalysisResult
+		json.Unmarshal([]byte(events[2].data), &result)
+		assert.True(t, result.Cached)
+		assert.Equal(t, "João Silva, atacante com excelente finalização", result.Analysis)
+	}
+}
@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// AnalysisHistoryFilter representa os filtros e a paginação do histórico de análises
+type AnalysisHistoryFilter struct {
+	Season string `form:"season"`
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+}
+
+// FieldChange é um campo com valores diferentes entre duas análises
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// TextChange é um trecho do diff do texto: "equal", "delete" (só na primeira
+// análise) ou "insert" (só na segunda)
+type TextChange struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// AnalysisDiff compara duas análises do mesmo jogador
+type AnalysisDiff struct {
+	From     models.Analysis `json:"from"`
+	To       models.Analysis `json:"to"`
+	Changes  []FieldChange   `json:"changes"`
+	Insights struct {
+		Added   []string `json:"added"`
+		Removed []string `json:"removed"`
+	} `json:"insights"`
+	Text []TextChange `json:"text"`
+}
+
+// analysisField define um campo comparado no diff: o nome (igual ao do JSON) e o valor
+type analysisField struct {
+	name  string
+	value func(analysis models.Analysis) interface{}
+}
+
+// analysisDiffFields são os campos comparados, na ordem em que aparecem no diff
+var analysisDiffFields = []analysisField{
+	{"rating", func(a models.Analysis) interface{} { return a.Rating }},
+	{"ai_used", func(a models.Analysis) interface{} { return a.AIUsed }},
+	{"ai_status", func(a models.Analysis) interface{} { return a.AIStatus }},
+	{"season", func(a models.Analysis) interface{} { return a.Season }},
+	{"report.recommended_level", func(a models.Analysis) interface{} { return reportOf(a).RecommendedLevel }},
+	{"report.suggested_rating", func(a models.Analysis) interface{} { return reportOf(a).SuggestedRating }},
+	{"provider", func(a models.Analysis) interface{} { return a.Provider }},
+	{"model", func(a models.Analysis) interface{} { return a.ModelName }},
+	{"prompt_version", func(a models.Analysis) interface{} { return a.PromptVersion }},
+	{"temperature", func(a models.Analysis) interface{} { return a.Temperature }},
+	{"player.name", func(a models.Analysis) interface{} { return a.Player.Name }},
+	{"player.age", func(a models.Analysis) interface{} { return a.Player.Age }},
+	{"player.position", func(a models.Analysis) interface{} { return a.Player.Position }},
+	{"player.team", func(a models.Analysis) interface{} { return a.Player.Team }},
+	{"player.goals", func(a models.Analysis) interface{} { return a.Player.Goals }},
+	{"player.tackles", func(a models.Analysis) interface{} { return a.Player.Tackles }},
+	{"player.passes", func(a models.Analysis) interface{} { return a.Player.Passes }},
+	{"player.version", func(a models.Analysis) interface{} { return a.Player.Version }},
+	{"player.games", func(a models.Analysis) interface{} { return a.Player.Games }},
+	{"player.minutes_played", func(a models.Analysis) interface{} { return a.Player.MinutesPlayed }},
+	{"player.efficiency", func(a models.Analysis) interface{} { return roundStat(a.Player.Efficiency) }},
+	{"player.performance_rank", func(a models.Analysis) interface{} { return a.Player.PerformanceRank }},
+}
+
+// reportOf retorna a análise estruturada, vazia quando a análise é estática
+func reportOf(analysis models.Analysis) models.ScoutReport {
+	if analysis.Report == nil {
+		return models.ScoutReport{}
+	}
+	return *analysis.Report
+}
+
+// recordAnalysis grava a análise no histórico e preenche result.AnalysisID; uma
+// falha ao gravar é apenas registrada no log, sem afetar a resposta
+func recordAnalysis(db *gorm.DB, stats PlayerStats, result *AnalysisResult, provider llm.Provider) {
+	if err := saveAnalysis(db, stats, result, provider); err != nil {
+		log.Printf("Erro ao registrar análise do jogador %d: %v", stats.Player.ID, err)
+	}
+}
+
+// saveAnalysis grava a análise no histórico e preenche result.AnalysisID
+func saveAnalysis(db *gorm.DB, stats PlayerStats, result *AnalysisResult, provider llm.Provider) error {
+	record := models.Analysis{
+		PlayerID: stats.Player.ID,
+		Season:   result.Season,
+		Rating:   result.Rating,
+		Text:     result.Analysis,
+		Insights: result.Insights,
+		AIUsed:   result.AIUsed,
+		Lang:     result.Lang,
+		Report:   result.Report,
+		Player:   playerSnapshot(stats),
+	}
+	if result.AIStatus != nil && result.AIStatus.Status != AIStatusOK {
+		record.AIStatus = result.AIStatus.Status
+	}
+
+	// O modelo só é registrado quando o texto veio dele
+	if result.AIUsed {
+		settings := llm.Describe(provider)
+		record.Provider = settings.Provider
+		record.ModelName = settings.Model
+		record.Temperature = settings.Temperature
+		record.PromptVersion = result.PromptVersion
+	}
+
+	if err := db.Create(&record).Error; err != nil {
+		return err
+	}
+	result.AnalysisID = record.ID
+	return nil
+}
+
+// GetPlayerAnalyses lista as análises já geradas para o jogador, da mais recente
+// para a mais antiga, com a mesma paginação da listagem de jogadores
+func GetPlayerAnalyses(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		player, ok := findHistoryPlayer(c, db)
+		if !ok {
+			return
+		}
+
+		var filter AnalysisHistoryFilter
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros inválidos: " + err.Error()})
+			return
+		}
+		if filter.Page == 0 {
+			filter.Page = 1
+		}
+		if filter.Limit == 0 {
+			filter.Limit = defaultPageLimit
+		} else if filter.Limit > maxPageLimit {
+			filter.Limit = maxPageLimit
+		}
+
+		query := db.Model(&models.Analysis{}).Where("player_id = ?", player.ID)
+		if filter.Season != "" {
+			query = query.Where("season = ?", filter.Season)
+		}
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao contar análises: " + err.Error()})
+			return
+		}
+
+		analyses := []models.Analysis{}
+		err := query.Order("created_at DESC, id DESC").
+			Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit).
+			Find(&analyses).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar análises: " + err.Error()})
+			return
+		}
+
+		setPaginationHeaders(c, filter.Page, filter.Limit, total)
+		c.JSON(http.StatusOK, analyses)
+	}
+}
+
+// GetAnalysisByID retorna uma análise do histórico
+func GetAnalysisByID(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		var analysis models.Analysis
+		if err := db.First(&analysis, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Análise não encontrada"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar análise: " + err.Error()})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, analysis)
+	}
+}
+
+// DiffPlayerAnalyses compara duas análises do jogador (?from=ID&to=ID): campos
+// alterados, insights adicionados e removidos e o diff do texto por frase
+func DiffPlayerAnalyses(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		player, ok := findHistoryPlayer(c, db)
+		if !ok {
+			return
+		}
+
+		fromID, errFrom := strconv.Atoi(c.Query("from"))
+		toID, errTo := strconv.Atoi(c.Query("to"))
+		if errFrom != nil || errTo != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Informe os IDs das análises em from e to"})
+			return
+		}
+
+		var from, to models.Analysis
+		for _, target := range []struct {
+			id       int
+			analysis *models.Analysis
+		}{{fromID, &from}, {toID, &to}} {
+			err := db.Where("player_id = ?", player.ID).First(target.analysis, target.id).Error
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Análise não encontrada"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar análise: " + err.Error()})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, diffAnalyses(from, to))
+	}
+}
+
+// findHistoryPlayer busca o jogador do parâmetro :id, respondendo o erro quando não encontra
+func findHistoryPlayer(c *gin.Context, db *gorm.DB) (models.Player, bool) {
+	var player models.Player
+	id := c.Param("id")
+
+	// Validação do ID
+	if _, err := strconv.Atoi(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return player, false
+	}
+
+	if err := db.First(&player, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+		}
+		return player, false
+	}
+
+	return player, true
+}
+
+// diffAnalyses monta a comparação entre duas análises
+func diffAnalyses(from, to models.Analysis) AnalysisDiff {
+	diff := AnalysisDiff{From: from, To: to, Changes: []FieldChange{}}
+	for _, field := range analysisDiffFields {
+		if before, after := field.value(from), field.value(to); before != after {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field.name, From: before, To: after})
+		}
+	}
+
+	diff.Insights.Added = missingFrom(to.Insights, from.Insights)
+	diff.Insights.Removed = missingFrom(from.Insights, to.Insights)
+	diff.Text = diffText(from.Text, to.Text)
+	return diff
+}
+
+// missingFrom retorna, na ordem original, os itens de items que não estão em other
+func missingFrom(items, other []string) []string {
+	present := make(map[string]bool, len(other))
+	for _, item := range other {
+		present[item] = true
+	}
+
+	missing := []string{}
+	for _, item := range items {
+		if !present[item] {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// diffText compara os textos frase a frase pela maior subsequência comum
+func diffText(from, to string) []TextChange {
+	a, b := splitSentences(from), splitSentences(to)
+
+	// lcs[i][j] é o tamanho da maior subsequência comum entre a[i:] e b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := []TextChange{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			changes = append(changes, TextChange{Op: "equal", Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, TextChange{Op: "delete", Text: a[i]})
+			i++
+		default:
+			changes = append(changes, TextChange{Op: "insert", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		changes = append(changes, TextChange{Op: "delete", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		changes = append(changes, TextChange{Op: "insert", Text: b[j]})
+	}
+	return changes
+}
+
+// splitSentences divide o texto em linhas e as linhas em frases terminadas por
+// ".", "!" ou "?" seguidos de espaço (números como 0.5 não são separados)
+func splitSentences(text string) []string {
+	var sentences []string
+	add := func(sentence string) {
+		if sentence = strings.TrimSpace(sentence); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		start := 0
+		for i := 0; i < len(line); i++ {
+			end := line[i] == '.' || line[i] == '!' || line[i] == '?'
+			if end && (i+1 == len(line) || line[i+1] == ' ') {
+				add(line[start : i+1])
+				start = i + 1
+			}
+		}
+		add(line[start:])
+	}
+	return sentences
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzePlayerRecordsHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{Response: reportJSON("João Silva é um finalizador nato. Precisa melhorar o passe.")}))
+	router.PUT("/players/:id", UpdatePlayer(db))
+	router.GET("/players/:id/analyses", GetPlayerAnalyses(db))
+	router.GET("/players/:id/analyses/diff", DiffPlayerAnalyses(db))
+	router.GET("/analyses/:id", GetAnalysisByID(db))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Primeira análise estática; depois o jogador evolui e é analisado com IA
+	var static AnalysisResult
+	json.Unmarshal(get("/analyze/players/1").Body.Bytes(), &static)
+	assert.NotZero(t, static.AnalysisID)
+
+	db.Model(&models.Player{}).Where("id = 1").Updates(map[string]interface{}{"goals": 25, "version": 2})
+
+	var withAI AnalysisResult
+	json.Unmarshal(get("/analyze/players/1?ai=true").Body.Bytes(), &withAI)
+	assert.NotEqual(t, static.AnalysisID, withAI.AnalysisID)
+
+	// Histórico do mais recente para o mais antigo, com o modelo só na análise com IA
+	w := get("/players/1/analyses")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	var history []models.Analysis
+	json.Unmarshal(w.Body.Bytes(), &history)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, withAI.AnalysisID, history[0].ID)
+		assert.True(t, history[0].AIUsed)
+		assert.Equal(t, llm.ProviderFake, history[0].Provider)
+		assert.Equal(t, withAI.PromptVersion, history[0].PromptVersion)
+		assert.NotEmpty(t, history[0].PromptVersion)
+		assert.Equal(t, 25, history[0].Player.Goals)
+		assert.Equal(t, withAI.Insights, history[0].Insights)
+
+		assert.False(t, history[1].AIUsed)
+		assert.Empty(t, history[1].Provider)
+		assert.Equal(t, 15, history[1].Player.Goals)
+	}
+
+	w = get(fmt.Sprintf("/analyses/%d", static.AnalysisID))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusNotFound, get("/analyses/999").Code)
+
+	// Diff entre as duas análises
+	w = get(fmt.Sprintf("/players/1/analyses/diff?from=%d&to=%d", static.AnalysisID, withAI.AnalysisID))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var diff AnalysisDiff
+	json.Unmarshal(w.Body.Bytes(), &diff)
+
+	changed := map[string]bool{}
+	for _, change := range diff.Changes {
+		changed[change.Field] = true
+	}
+	assert.True(t, changed["ai_used"])
+	assert.True(t, changed["player.goals"])
+	assert.True(t, changed["prompt_version"])
+	assert.False(t, changed["player.name"])
+	assert.Equal(t, withAI.Insights, diff.Insights.Added)
+	assert.NotEmpty(t, diff.Insights.Removed)
+
+	// Análises de outro jogador ou inexistentes não entram no diff
+	assert.Equal(t, http.StatusBadRequest, get("/players/1/analyses/diff?from=1").Code)
+	assert.Equal(t, http.StatusNotFound, get("/players/1/analyses/diff?from=1&to=999").Code)
+	assert.Equal(t, http.StatusNotFound, get("/players/999/analyses").Code)
+}
+
+func TestDiffText(t *testing.T) {
+	changes := diffText(
+		"Jogador com 0.5 gols por jogo. Bom passe.\nIdade ideal.",
+		"Jogador com 0.5 gols por jogo. Passe excelente! Idade ideal.",
+	)
+
+	assert.Equal(t, []TextChange{
+		{Op: "equal", Text: "Jogador com 0.5 gols por jogo."},
+		{Op: "delete", Text: "Bom passe."},
+		{Op: "insert", Text: "Passe excelente!"},
+		{Op: "equal", Text: "Idade ideal."},
+	}, changes)
+	assert.Empty(t, diffText("", ""))
+}
+
+func TestPurgePlayerRemovesHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AdminAccess.Token = "segredo"
+	defer func() { AdminAccess.Token = "" }()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
+	router.DELETE("/players/:id", DeletePlayer(db))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest("DELETE", "/players/1?purge=true", nil)
+	req.Header.Set("X-Admin-Token", "segredo")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var count int64
+	db.Unscoped().Model(&models.Analysis{}).Count(&count)
+	assert.Zero(t, count)
+}
@@ -1,13 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
 	"github.com/mvcbotelho/scout-ai/models"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 )
 
@@ -21,87 +27,230 @@ type AnalysisResult struct {
 	Position   string   `json:"position"`
 	Team       string   `json:"team"`
 	AIUsed     bool     `json:"ai_used"`
+
+	// Análise estruturada da IA (resumo, pontos fortes e fracos, nível
+	// recomendado, riscos e nota sugerida); ausente na análise estática
+	Report *models.ScoutReport `json:"report,omitempty"`
+
+	// Presente quando a IA foi solicitada: explica o resultado da chamada ao LLM
+	AIStatus *AIStatus `json:"ai_status,omitempty"`
+
+	// Campos do cadastro omitidos do prompt por parecerem instruções ao modelo
+	PromptWarnings []string `json:"prompt_warnings,omitempty"`
+
+	// ID da análise no histórico, quando ela foi registrada
+	AnalysisID uint `json:"analysis_id,omitempty"`
+
+	// Versão do template de prompt, quando a análise veio da IA
+	PromptVersion string `json:"prompt_version,omitempty"`
+
+	// Cached indica que o texto da IA veio do cache de análises
+	Cached bool `json:"cached,omitempty"`
+
+	// Idioma dos textos da análise
+	Lang string `json:"lang,omitempty"`
+
+	// Preenchidos apenas em análises restritas a uma temporada
+	Season string       `json:"season,omitempty"`
+	Trend  *SeasonTrend `json:"trend,omitempty"`
 }
 
 // PlayerStats representa estatísticas do jogador
 type PlayerStats struct {
 	Player models.Player `json:"player"`
 	Stats  struct {
+		Games           int     `json:"games"`
+		GamesEstimated  bool    `json:"games_estimated"`
+		MinutesPlayed   int     `json:"minutes_played"`
 		GoalsPerGame    float64 `json:"goals_per_game"`
 		TacklesPerGame  float64 `json:"tackles_per_game"`
 		PassesPerGame   float64 `json:"passes_per_game"`
+		GoalsPer90      float64 `json:"goals_per_90"`
+		TacklesPer90    float64 `json:"tackles_per_90"`
+		PassesPer90     float64 `json:"passes_per_90"`
 		Efficiency      float64 `json:"efficiency"`
 		PerformanceRank string  `json:"performance_rank"`
 	} `json:"stats"`
+
+	// Totais registrados antes da primeira atuação, fora das estatísticas
+	// acima, quando houver
+	PriorTotals *StatTotals `json:"prior_totals,omitempty"`
+
+	// Temporada das estatísticas e tendência em relação à anterior, quando houver
+	Season string       `json:"season,omitempty"`
+	Trend  *SeasonTrend `json:"trend,omitempty"`
 }
 
-// AnalyzePlayer analisa um jogador específico
-func AnalyzePlayer(db *gorm.DB) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id := c.Param("id")
+// StatTotals são totais de gols, tackles e passes
+type StatTotals struct {
+	Goals   int `json:"goals"`
+	Tackles int `json:"tackles"`
+	Passes  int `json:"passes"`
+}
+
+// defaultSeasonGames é usado apenas para jogadores sem atuações registradas
+const defaultSeasonGames = 30
 
-		// Validação do ID
-		if _, err := strconv.Atoi(id); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+// AnalyzePlayer analisa um jogador específico; com ai=true usa o provedor de LLM
+// e, com strict_ai=true, responde erro em vez de cair na análise estática.
+// Os textos seguem o idioma de ?lang ou Accept-Language (ver requestLang).
+// Cada análise gerada é registrada no histórico (ver GetPlayerAnalyses)
+func AnalyzePlayer(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
 			return
 		}
-
-		var player models.Player
-		if err := db.First(&player, id).Error; err != nil {
-			if err == gorm.ErrRecordNotFound {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
-			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
-			}
+		stats, ok := loadPlayerStats(c, db, lang)
+		if !ok {
 			return
 		}
 
-		// Verificar se deve usar Ollama
-		useAI := c.Query("ai") == "true" || c.Query("ai") == "1"
+		// Verificar se deve usar o LLM
+		options := parseAIOptions(c, lang)
 
 		// Gerar análise
 		var analysis AnalysisResult
-		if useAI {
-			analysis = generatePlayerAnalysisWithAI(player)
+		if options.Enabled {
+			var err error
+			if analysis, err = generatePlayerAnalysisWithAI(c.Request.Context(), provider, stats.Player, stats, options); err != nil {
+				respondAIError(c, err, lang)
+				return
+			}
+			setCacheHeaders(c, cacheCount(analysis.Cached), cacheCount(!analysis.Cached))
 		} else {
-			analysis = generatePlayerAnalysis(player)
+			analysis = generatePlayerAnalysis(stats, lang)
 		}
 
+		recordAnalysis(db, stats, &analysis, provider)
 		c.JSON(http.StatusOK, analysis)
 	}
 }
 
+// loadPlayerStats busca o jogador do parâmetro :id e calcula suas estatísticas,
+// restritas à temporada de ?season quando informada; responde o erro, no
+// idioma lang, quando falha
+func loadPlayerStats(c *gin.Context, db *gorm.DB, lang string) (PlayerStats, bool) {
+	return loadPlayerStatsByID(c, db, c.Param("id"), lang)
+}
+
+// loadPlayerStatsByID é loadPlayerStats para o jogador informado
+func loadPlayerStatsByID(c *gin.Context, db *gorm.DB, id, lang string) (PlayerStats, bool) {
+	// Validação do ID
+	if _, err := strconv.Atoi(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_id")})
+		return PlayerStats{}, false
+	}
+
+	var player models.Player
+	if err := db.Preload("Appearances").First(&player, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.player_not_found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_player", err)})
+		}
+		return PlayerStats{}, false
+	}
+
+	// Análise restrita a uma temporada (?season=2025) ou de todas as atuações
+	seasonParam := c.Query("season")
+	if seasonParam == "" {
+		return calculatePlayerStats(player), true
+	}
+
+	year, err := strconv.Atoi(seasonParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_season")})
+		return PlayerStats{}, false
+	}
+
+	stats, err := seasonScopedStats(db, player, year)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.season_not_found")})
+		} else if errors.Is(err, errNoSeasonAppearances) {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.no_season_appearances")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_appearances", err)})
+		}
+		return PlayerStats{}, false
+	}
+	return stats, true
+}
+
+// DefaultAnalyzeWorkers é o padrão de jogadores analisados com IA ao mesmo tempo
+// em AnalyzeAllPlayers
+const DefaultAnalyzeWorkers = 4
+
+// FanOutConfig configura as análises com IA em paralelo de AnalyzeAllPlayers
+type FanOutConfig struct {
+	// Workers é o máximo de jogadores analisados ao mesmo tempo em cada
+	// requisição; o limite global de chamadas ao LLM continua valendo
+	Workers int
+
+	// PlayerTimeout limita a análise de cada jogador, incluindo a espera por
+	// uma vaga no LLM e as novas tentativas; zero desabilita o limite
+	PlayerTimeout time.Duration
+}
+
+// AnalyzeAllFanOut configuração padrão
+var AnalyzeAllFanOut = FanOutConfig{
+	Workers: DefaultAnalyzeWorkers,
+}
+
 // AnalyzeAllPlayers analisa todos os jogadores
-func AnalyzeAllPlayers(db *gorm.DB) gin.HandlerFunc {
+func AnalyzeAllPlayers(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
 		var players []models.Player
-		if err := db.Find(&players).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogadores: " + err.Error()})
+		if err := db.Preload("Appearances").Order("id").Find(&players).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_players", err)})
 			return
 		}
 
-		// Verificar se deve usar Ollama
-		useAI := c.Query("ai") == "true" || c.Query("ai") == "1"
+		// Verificar se deve usar o LLM
+		options := parseAIOptions(c, lang)
 
-		var analyses []AnalysisResult
-		for _, player := range players {
-			var analysis AnalysisResult
-			if useAI {
-				analysis = generatePlayerAnalysisWithAI(player)
-			} else {
-				analysis = generatePlayerAnalysis(player)
+		analyses := make([]AnalysisResult, len(players))
+		var cacheHits, cacheMisses int
+		if options.Enabled {
+			var err error
+			if analyses, err = analyzePlayersWithAI(c.Request.Context(), provider, players, options); err != nil {
+				respondAIError(c, err, lang)
+				return
+			}
+			for _, analysis := range analyses {
+				cacheHits += cacheCount(analysis.Cached)
+				cacheMisses += cacheCount(!analysis.Cached)
+			}
+		} else {
+			for i, player := range players {
+				analyses[i] = generatePlayerAnalysis(calculatePlayerStats(player), lang)
 			}
-			analyses = append(analyses, analysis)
 		}
 
 		// Gerar análise comparativa
-		comparativeAnalysis := generateComparativeAnalysis(players)
-
-		// Se usar AI, gerar análise comparativa com Ollama
-		if useAI {
-			if comparativeText, err := generateComparativeOllamaAnalysis(players, DefaultOllamaConfig); err == nil {
+		comparativeAnalysis := generateComparativeAnalysis(players, lang)
+
+		// Se usar AI, gerar análise comparativa com o LLM
+		if options.Enabled {
+			comparativeText, promptVersion, cached, err := comparativeAIText(c.Request.Context(), provider, players, options)
+			if fatal := fatalAIError(c.Request.Context(), err, options.Strict); fatal != nil {
+				respondAIError(c, fatal, lang)
+				return
+			}
+			if err == nil {
 				comparativeAnalysis["ai_comparative_analysis"] = comparativeText
+				comparativeAnalysis["ai_comparative_prompt_version"] = promptVersion
 			}
+			comparativeAnalysis["ai_comparative_status"] = newAIStatus(err, lang)
+			cacheHits += cacheCount(cached)
+			cacheMisses += cacheCount(!cached)
+			setCacheHeaders(c, cacheHits, cacheMisses)
 		}
 
 		c.JSON(http.StatusOK, gin.H{
@@ -112,80 +261,139 @@ func AnalyzeAllPlayers(db *gorm.DB) gin.HandlerFunc {
 }
 
 // ComparePlayers compara dois ou mais jogadores
-func ComparePlayers(db *gorm.DB) gin.HandlerFunc {
+func ComparePlayers(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
 		ids := c.QueryArray("ids")
 		if len(ids) < 2 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "É necessário pelo menos 2 IDs de jogadores para comparação"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.compare_min_players")})
 			return
 		}
 
 		var players []models.Player
-		if err := db.Where("id IN ?", ids).Find(&players).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogadores: " + err.Error()})
+		if err := db.Preload("Appearances").Where("id IN ?", ids).Find(&players).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_players", err)})
 			return
 		}
 
 		if len(players) != len(ids) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Alguns jogadores não foram encontrados"})
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.players_not_found")})
 			return
 		}
 
-		// Verificar se deve usar Ollama
-		useAI := c.Query("ai") == "true" || c.Query("ai") == "1"
+		// Verificar se deve usar o LLM
+		options := parseAIOptions(c, lang)
 
-		comparison := generatePlayerComparison(players)
+		comparison := generatePlayerComparison(players, lang)
 
-		// Se usar AI, adicionar análise comparativa com Ollama
-		if useAI {
-			if comparativeText, err := generateComparativeOllamaAnalysis(players, DefaultOllamaConfig); err == nil {
+		// Se usar AI, adicionar análise comparativa com o LLM
+		if options.Enabled {
+			comparativeText, promptVersion, cached, err := comparativeAIText(c.Request.Context(), provider, players, options)
+			if fatal := fatalAIError(c.Request.Context(), err, options.Strict); fatal != nil {
+				respondAIError(c, fatal, lang)
+				return
+			}
+			if err == nil {
 				comparison["ai_comparative_analysis"] = comparativeText
+				comparison["ai_comparative_prompt_version"] = promptVersion
 			}
+			comparison["ai_comparative_status"] = newAIStatus(err, lang)
+			setCacheHeaders(c, cacheCount(cached), cacheCount(!cached))
 		}
 
 		c.JSON(http.StatusOK, comparison)
 	}
 }
 
-// generatePlayerAnalysisWithAI gera análise usando Ollama
-func generatePlayerAnalysisWithAI(player models.Player) AnalysisResult {
-	// Calcular estatísticas
-	stats := calculatePlayerStats(player)
-
-	// Gerar análise com Ollama
-	analysis, insights, err := generateOllamaAnalysis(player, stats, DefaultOllamaConfig)
+// errPlayerTimeout é a causa do fim do prazo de AnalyzeAllFanOut.PlayerTimeout.
+// Conta como tempo limite do LLM, e não como cancelamento da requisição
+var errPlayerTimeout = fmt.Errorf("%w: prazo da análise do jogador esgotado", llm.ErrTimeout)
+
+// generatePlayerAnalysisWithAI gera análise usando o provedor de LLM, ou a
+// reaproveita do cache de análises. Só retorna erro quando a falha do LLM não
+// pode ser contornada (ver fatalAIError); no fallback, AIStatus informa o motivo
+func generatePlayerAnalysisWithAI(ctx context.Context, provider llm.Provider, player models.Player, stats PlayerStats, options aiOptions) (AnalysisResult, error) {
+	// Gerar análise com o LLM
+	var text string
+	var cached bool
+	prompt, err := createAnalysisPrompt(stats, options.Lang)
+	if err == nil {
+		key := playerAnalysisCacheKey(provider, prompt, stats)
+		text, cached, err = cachedAIText(ctx, key, playerCacheGroup(player.ID), options, func() (string, error) {
+			_, encoded, err := generateAIAnalysis(ctx, provider, prompt, player, options.Lang)
+			return encoded, err
+		})
+	}
+	var report models.ScoutReport
+	if err == nil {
+		report, err = parseScoutReport(text)
+	}
+	if err != nil && errors.Is(context.Cause(ctx), errPlayerTimeout) {
+		err = errPlayerTimeout
+	}
+	if fatal := fatalAIError(ctx, err, options.Strict); fatal != nil {
+		return AnalysisResult{}, fatal
+	}
 
-	// Se houver erro com Ollama, usar análise estática como fallback
-	if err != nil {
-		analysis = generateAnalysisText(player, stats, generateInsights(player, stats))
-		insights = generateInsights(player, stats)
+	// Se houver erro com o LLM, usar análise estática como fallback
+	result := generatePlayerAnalysis(stats, options.Lang)
+	if err == nil {
+		applyScoutReport(&result, report)
+		result.PromptVersion = prompt.Version
+		result.Cached = cached
 	}
+	result.AIStatus = newAIStatus(err, options.Lang)
+	result.PromptWarnings = promptWarnings(options.Lang, player)
+	return result, nil
+}
 
-	// Calcular rating (1-10)
-	rating := calculateRating(player, stats)
+// analyzePlayersWithAI analisa os jogadores com o LLM em paralelo, no máximo
+// AnalyzeAllFanOut.Workers ao mesmo tempo. Cada resultado ocupa a posição do
+// jogador, então a ordem não depende de quem termina primeiro; o primeiro erro
+// fatal cancela as análises que ainda não terminaram
+func analyzePlayersWithAI(ctx context.Context, provider llm.Provider, players []models.Player, options aiOptions) ([]AnalysisResult, error) {
+	analyses := make([]AnalysisResult, len(players))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(AnalyzeAllFanOut.Workers, 1))
+	for i, player := range players {
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
 
-	return AnalysisResult{
-		PlayerID:   player.ID,
-		PlayerName: player.Name,
-		Analysis:   analysis,
-		Insights:   insights,
-		Rating:     rating,
-		Position:   player.Position,
-		Team:       player.Team,
-		AIUsed:     err == nil, // true se Ollama funcionou
+			// Um prazo só para o jogador inteiro: as novas tentativas com a
+			// resposta inválida não ganham prazo próprio
+			playerCtx := groupCtx
+			if timeout := AnalyzeAllFanOut.PlayerTimeout; timeout > 0 {
+				var cancel context.CancelFunc
+				playerCtx, cancel = context.WithTimeoutCause(groupCtx, timeout, errPlayerTimeout)
+				defer cancel()
+			}
+			analysis, err := generatePlayerAnalysisWithAI(playerCtx, provider, player, calculatePlayerStats(player), options)
+			analyses[i] = analysis
+			return err
+		})
 	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return analyses, nil
 }
 
-// generatePlayerAnalysis gera análise individual do jogador (versão estática)
-func generatePlayerAnalysis(player models.Player) AnalysisResult {
-	// Calcular estatísticas
-	stats := calculatePlayerStats(player)
+// generatePlayerAnalysis gera análise individual do jogador no idioma lang (versão estática)
+func generatePlayerAnalysis(stats PlayerStats, lang string) AnalysisResult {
+	// Os totais de stats.Player são os da mesma base de jogos das médias
+	player := stats.Player
 
 	// Gerar insights baseados nos dados
-	insights := generateInsights(player, stats)
+	insights := generateInsights(player, stats, lang)
 
 	// Gerar análise textual
-	analysis := generateAnalysisText(player, stats, insights)
+	analysis := generateAnalysisText(player, stats, insights, lang)
 
 	// Calcular rating (1-10)
 	rating := calculateRating(player, stats)
@@ -199,19 +407,54 @@ func generatePlayerAnalysis(player models.Player) AnalysisResult {
 		Position:   player.Position,
 		Team:       player.Team,
 		AIUsed:     false,
+		Lang:       lang,
+		Season:     stats.Season,
+		Trend:      localizedTrend(lang, stats.Trend),
 	}
 }
 
 // calculatePlayerStats calcula estatísticas do jogador
 func calculatePlayerStats(player models.Player) PlayerStats {
-	stats := PlayerStats{Player: player}
+	// Com atuações registradas, os números vêm só das partidas: os totais de
+	// stats.Player passam a ser a soma delas e os anteriores à primeira
+	// atuação, que não têm jogos nem minutos, ficam em PriorTotals. Sem
+	// atuações, os totais do jogador são divididos por uma temporada estimada
+	var priorTotals *StatTotals
+	if len(player.Appearances) > 0 {
+		if player.PriorGoals > 0 || player.PriorTackles > 0 || player.PriorPasses > 0 {
+			priorTotals = &StatTotals{Goals: player.PriorGoals, Tackles: player.PriorTackles, Passes: player.PriorPasses}
+		}
+		player.Goals, player.Tackles, player.Passes = 0, 0, 0
+		for _, appearance := range player.Appearances {
+			player.Goals += appearance.Goals
+			player.Tackles += appearance.Tackles
+			player.Passes += appearance.Passes
+		}
+	}
 
-	// Estatísticas básicas (assumindo 30 jogos por temporada)
-	games := 30.0
+	stats := PlayerStats{Player: player, PriorTotals: priorTotals}
+	if len(player.Appearances) > 0 {
+		stats.Stats.Games = len(player.Appearances)
+		for _, appearance := range player.Appearances {
+			stats.Stats.MinutesPlayed += appearance.Minutes
+		}
+	} else {
+		stats.Stats.Games = defaultSeasonGames
+		stats.Stats.GamesEstimated = true
+	}
+
+	games := float64(stats.Stats.Games)
 	stats.Stats.GoalsPerGame = float64(player.Goals) / games
 	stats.Stats.TacklesPerGame = float64(player.Tackles) / games
 	stats.Stats.PassesPerGame = float64(player.Passes) / games
 
+	if stats.Stats.MinutesPlayed > 0 {
+		minutes := float64(stats.Stats.MinutesPlayed)
+		stats.Stats.GoalsPer90 = float64(player.Goals) / minutes * 90
+		stats.Stats.TacklesPer90 = float64(player.Tackles) / minutes * 90
+		stats.Stats.PassesPer90 = float64(player.Passes) / minutes * 90
+	}
+
 	// Eficiência baseada na posição
 	switch strings.ToLower(player.Position) {
 	case "atacante":
@@ -228,92 +471,110 @@ func calculatePlayerStats(player models.Player) PlayerStats {
 
 	// Ranking de performance
 	if stats.Stats.Efficiency > 200 {
-		stats.Stats.PerformanceRank = "Excelente"
+		stats.Stats.PerformanceRank = rankExcellent
 	} else if stats.Stats.Efficiency > 150 {
-		stats.Stats.PerformanceRank = "Muito Bom"
+		stats.Stats.PerformanceRank = rankVeryGood
 	} else if stats.Stats.Efficiency > 100 {
-		stats.Stats.PerformanceRank = "Bom"
+		stats.Stats.PerformanceRank = rankGood
 	} else if stats.Stats.Efficiency > 50 {
-		stats.Stats.PerformanceRank = "Regular"
+		stats.Stats.PerformanceRank = rankAverage
 	} else {
-		stats.Stats.PerformanceRank = "Abaixo da Média"
+		stats.Stats.PerformanceRank = rankBelowAverage
 	}
 
 	return stats
 }
 
-// generateInsights gera insights baseados nos dados (versão estática)
-func generateInsights(player models.Player, stats PlayerStats) []string {
-	var insights []string
+// generateInsights gera insights baseados nos dados no idioma lang (versão estática)
+func generateInsights(player models.Player, stats PlayerStats, lang string) []string {
+	var keys []string
 
 	// Insights baseados na idade
 	if player.Age < 23 {
-		insights = append(insights, "Jogador jovem com potencial de desenvolvimento")
+		keys = append(keys, "insight.young")
 	} else if player.Age > 30 {
-		insights = append(insights, "Jogador experiente, pode ser importante para liderança")
+		keys = append(keys, "insight.experienced")
 	}
 
 	// Insights baseados na posição e estatísticas
 	switch strings.ToLower(player.Position) {
 	case "atacante":
 		if player.Goals > 15 {
-			insights = append(insights, "Artilheiro eficiente, excelente finalização")
+			keys = append(keys, "insight.forward_scorer")
 		}
 		if player.Passes > 100 {
-			insights = append(insights, "Atacante que também participa da construção do jogo")
+			keys = append(keys, "insight.forward_playmaker")
 		}
 	case "meio-campo", "meio-campista":
 		if player.Passes > 200 {
-			insights = append(insights, "Meio-campista com excelente visão de jogo")
+			keys = append(keys, "insight.midfielder_vision")
 		}
 		if player.Tackles > 50 {
-			insights = append(insights, "Meio-campista que também marca bem")
+			keys = append(keys, "insight.midfielder_tackling")
 		}
 	case "zagueiro":
 		if player.Tackles > 80 {
-			insights = append(insights, "Zagueiro com excelente marcação")
+			keys = append(keys, "insight.defender_tackling")
 		}
 		if player.Passes > 150 {
-			insights = append(insights, "Zagueiro com boa saída de bola")
+			keys = append(keys, "insight.defender_passing")
 		}
 	case "goleiro":
 		if player.Tackles > 10 {
-			insights = append(insights, "Goleiro com boa saída do gol")
+			keys = append(keys, "insight.goalkeeper_sweeper")
 		}
 	}
 
 	// Insights baseados no rating
-	if stats.Stats.PerformanceRank == "Excelente" {
-		insights = append(insights, "Performance excepcional na temporada")
-	} else if stats.Stats.PerformanceRank == "Abaixo da Média" {
-		insights = append(insights, "Necessita de melhoria no desempenho")
+	if stats.Stats.PerformanceRank == rankExcellent {
+		keys = append(keys, "insight.exceptional")
+	} else if stats.Stats.PerformanceRank == rankBelowAverage {
+		keys = append(keys, "insight.needs_improvement")
 	}
 
+	var insights []string
+	for _, key := range keys {
+		insights = append(insights, i18n.T(lang, key))
+	}
 	return insights
 }
 
-// generateAnalysisText gera análise textual do jogador (versão estática)
-func generateAnalysisText(player models.Player, stats PlayerStats, insights []string) string {
-	analysis := fmt.Sprintf("%s, %d anos, atua como %s no %s. ",
-		player.Name, player.Age, player.Position, player.Team)
+// generateAnalysisText gera análise textual do jogador no idioma lang (versão estática)
+func generateAnalysisText(player models.Player, stats PlayerStats, insights []string, lang string) string {
+	analysis := i18n.T(lang, "analysis.intro",
+		player.Name, player.Age, positionText(lang, player.Position), player.Team)
 
-	analysis += fmt.Sprintf("Na temporada, marcou %d gols, realizou %d tackles e %d passes. ",
-		player.Goals, player.Tackles, player.Passes)
+	if stats.Stats.GamesEstimated {
+		analysis += i18n.T(lang, "analysis.season_totals",
+			player.Goals, player.Tackles, player.Passes)
+	} else {
+		analysis += i18n.T(lang, "analysis.games_totals",
+			stats.Stats.Games, stats.Stats.MinutesPlayed, player.Goals, player.Tackles, player.Passes, stats.Stats.GoalsPerGame)
+	}
+	if prior := stats.PriorTotals; prior != nil {
+		analysis += i18n.T(lang, "analysis.prior_totals", prior.Goals, prior.Tackles, prior.Passes)
+	}
 
-	analysis += fmt.Sprintf("Sua eficiência geral é de %.1f pontos, classificando-o como %s. ",
-		stats.Stats.Efficiency, stats.Stats.PerformanceRank)
+	analysis += i18n.T(lang, "analysis.efficiency",
+		stats.Stats.Efficiency, labelText(lang, stats.Stats.PerformanceRank))
 
 	if len(insights) > 0 {
-		analysis += "Principais características: " + strings.Join(insights, "; ") + ". "
+		analysis += i18n.T(lang, "analysis.highlights", strings.Join(insights, "; "))
+	}
+
+	if stats.Trend != nil {
+		analysis += i18n.T(lang, "analysis.trend",
+			stats.Trend.FromSeason, labelText(lang, stats.Trend.Direction), stats.Trend.GoalsPerGameChange,
+			stats.Trend.TacklesPerGameChange, stats.Trend.PassesPerGameChange)
 	}
 
 	// Recomendações baseadas na análise
 	if stats.Stats.Efficiency > 150 {
-		analysis += "Recomendado para times de alto nível."
+		analysis += i18n.T(lang, "analysis.recommend_high")
 	} else if stats.Stats.Efficiency > 100 {
-		analysis += "Adequado para times de nível médio."
+		analysis += i18n.T(lang, "analysis.recommend_mid")
 	} else {
-		analysis += "Pode se beneficiar de mais tempo de desenvolvimento."
+		analysis += i18n.T(lang, "analysis.recommend_develop")
 	}
 
 	return analysis
@@ -352,9 +613,9 @@ func calculateRating(player models.Player, stats PlayerStats) int {
 }
 
 // generateComparativeAnalysis gera análise comparativa entre jogadores
-func generateComparativeAnalysis(players []models.Player) map[string]interface{} {
+func generateComparativeAnalysis(players []models.Player, lang string) map[string]interface{} {
 	if len(players) == 0 {
-		return map[string]interface{}{"message": "Nenhum jogador para análise"}
+		return map[string]interface{}{"message": i18n.T(lang, "analysis.no_players")}
 	}
 
 	// Estatísticas gerais
@@ -412,12 +673,12 @@ func generateComparativeAnalysis(players []models.Player) map[string]interface{}
 }
 
 // generatePlayerComparison compara jogadores específicos
-func generatePlayerComparison(players []models.Player) map[string]interface{} {
+func generatePlayerComparison(players []models.Player, lang string) map[string]interface{} {
 	var analyses []AnalysisResult
 	var comparison map[string]interface{}
 
 	for _, player := range players {
-		analysis := generatePlayerAnalysis(player)
+		analysis := generatePlayerAnalysis(calculatePlayerStats(player), lang)
 		analyses = append(analyses, analysis)
 	}
 
@@ -453,3 +714,29 @@ func generatePlayerComparison(players []models.Player) map[string]interface{} {
 
 	return comparison
 }
+
+// comparativeAIText gera a análise comparativa com o LLM, ou a reaproveita do
+// cache, retornando também a versão do prompt usado
+func comparativeAIText(ctx context.Context, provider llm.Provider, players []models.Player, options aiOptions) (string, string, bool, error) {
+	if len(players) == 0 {
+		return i18n.T(options.Lang, "analysis.no_players_comparative"), "", false, nil
+	}
+
+	prompt, err := createComparativePrompt(players, options.Lang)
+	if err != nil {
+		return "", "", false, err
+	}
+	key := comparativeAnalysisCacheKey(provider, prompt, players)
+	text, cached, err := cachedAIText(ctx, key, comparativeCacheGroup, options, func() (string, error) {
+		return generateComparativeAIAnalysis(ctx, provider, prompt, players)
+	})
+	return text, prompt.Version, cached, err
+}
+
+// cacheCount converte o resultado de uma consulta ao cache em contagem
+func cacheCount(counted bool) int {
+	if counted {
+		return 1
+	}
+	return 0
+}
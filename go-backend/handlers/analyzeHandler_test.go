@@ -1,12 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
 	"github.com/mvcbotelho/scout-ai/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,7 +24,7 @@ func TestAnalyzePlayer(t *testing.T) {
 	db := setupTestDB()
 
 	router := gin.New()
-	router.GET("/analyze/players/:id", AnalyzePlayer(db))
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
 
 	// Criar um jogador primeiro
 	player := models.Player{
@@ -50,7 +58,7 @@ func TestAnalyzePlayerNotFound(t *testing.T) {
 	db := setupTestDB()
 
 	router := gin.New()
-	router.GET("/analyze/players/:id", AnalyzePlayer(db))
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
 
 	req, _ := http.NewRequest("GET", "/analyze/players/999", nil)
 	w := httptest.NewRecorder()
@@ -64,7 +72,7 @@ func TestAnalyzeAllPlayers(t *testing.T) {
 	db := setupTestDB()
 
 	router := gin.New()
-	router.GET("/analyze/players", AnalyzeAllPlayers(db))
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, &llm.Fake{}))
 
 	// Criar alguns jogadores
 	players := []models.Player{
@@ -113,7 +121,7 @@ func TestComparePlayers(t *testing.T) {
 	db := setupTestDB()
 
 	router := gin.New()
-	router.GET("/analyze/compare", ComparePlayers(db))
+	router.GET("/analyze/compare", ComparePlayers(db, &llm.Fake{}))
 
 	// Criar alguns jogadores
 	players := []models.Player{
@@ -162,7 +170,7 @@ func TestComparePlayersInsufficient(t *testing.T) {
 	db := setupTestDB()
 
 	router := gin.New()
-	router.GET("/analyze/compare", ComparePlayers(db))
+	router.GET("/analyze/compare", ComparePlayers(db, &llm.Fake{}))
 
 	req, _ := http.NewRequest("GET", "/analyze/compare?ids=1", nil)
 	w := httptest.NewRecorder()
@@ -202,7 +210,7 @@ func TestGenerateInsights(t *testing.T) {
 	}
 
 	stats := calculatePlayerStats(player)
-	insights := generateInsights(player, stats)
+	insights := generateInsights(player, stats, i18n.Default)
 
 	assert.NotEmpty(t, insights)
 	assert.IsType(t, []string{}, insights)
@@ -225,3 +233,329 @@ func TestCalculateRating(t *testing.T) {
 	assert.GreaterOrEqual(t, rating, 1)
 	assert.LessOrEqual(t, rating, 10)
 }
+
+func TestAnalyzeExcludesDeletedPlayers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, &llm.Fake{}))
+
+	deleted := models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15}
+	db.Create(&deleted)
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Passes: 350})
+	db.Delete(&deleted)
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/analyze/players", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "João Silva")
+}
+
+func TestAnalyzePlayerWithAI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	analyze := func(provider llm.Provider) AnalysisResult {
+		router := gin.New()
+		router.GET("/analyze/players/:id", AnalyzePlayer(db, provider))
+
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	fake := &llm.Fake{Response: reportJSON("João Silva é um atacante com excelente finalização. Joga pelos lados")}
+	response := analyze(fake)
+	assert.True(t, response.AIUsed)
+	assert.Equal(t, AIStatusOK, response.AIStatus.Status)
+	assert.Equal(t, "João Silva é um atacante com excelente finalização. Joga pelos lados", response.Analysis)
+	assert.Equal(t, []string{"Finalização precisa", "Pouca participação defensiva"}, response.Insights)
+	if assert.Len(t, fake.Requests(), 1) {
+		assert.Contains(t, fake.Requests()[0].Messages[0].Content, "João Silva")
+	}
+
+	// Falha do provedor cai na análise estática, com o motivo em ai_status
+	response = analyze(&llm.Fake{Err: errors.New("indisponível")})
+	assert.False(t, response.AIUsed)
+	assert.NotEmpty(t, response.Analysis)
+	if assert.NotNil(t, response.AIStatus) {
+		assert.Equal(t, AIStatusUnavailable, response.AIStatus.Status)
+		assert.Contains(t, response.AIStatus.Detail, "indisponível")
+	}
+}
+
+func TestAnalyzePlayerStrictAI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	cases := []struct {
+		err    error
+		status string
+		code   int
+	}{
+		{fmt.Errorf("%w (1s)", llm.ErrTimeout), AIStatusTimeout, http.StatusGatewayTimeout},
+		{fmt.Errorf("ollama: %w", &llm.StatusError{StatusCode: http.StatusNotFound, Body: "model not found"}), AIStatusModelMissing, http.StatusBadGateway},
+		{fmt.Errorf("ollama: %w: resposta vazia", llm.ErrInvalidOutput), AIStatusInvalidOutput, http.StatusBadGateway},
+		{fmt.Errorf("%w: 5 falhas seguidas", llm.ErrCircuitOpen), AIStatusUnavailable, http.StatusServiceUnavailable},
+	}
+	for _, tc := range cases {
+		t.Run(tc.status, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{Err: tc.err}))
+
+			// Sem strict_ai a falha é contornada e explicada em ai_status
+			req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			var analysis AnalysisResult
+			json.Unmarshal(w.Body.Bytes(), &analysis)
+			if assert.NotNil(t, analysis.AIStatus) {
+				assert.Equal(t, tc.status, analysis.AIStatus.Status)
+			}
+
+			// strict_ai implica ai=true e responde o erro
+			req, _ = http.NewRequest("GET", "/analyze/players/1?strict_ai=true", nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.code, w.Code)
+			var response struct {
+				Error    string   `json:"error"`
+				AIStatus AIStatus `json:"ai_status"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, tc.status, response.AIStatus.Status)
+			assert.NotEmpty(t, response.Error)
+		})
+	}
+}
+
+func TestAnalyzePlayerAITimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{Err: fmt.Errorf("%w (1s)", llm.ErrTimeout)}))
+
+	analyze := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Padrão: análise estática como fallback
+	w := analyze()
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response AnalysisResult
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.False(t, response.AIUsed)
+
+	AIBehavior.FallbackOnTimeout = false
+	defer func() { AIBehavior.FallbackOnTimeout = true }()
+
+	assert.Equal(t, http.StatusGatewayTimeout, analyze().Code)
+}
+
+func TestAnalyzePlayerClientGone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	fake := &llm.Fake{Response: reportJSON("João Silva, atacante com excelente finalização")}
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/analyze/players/1?ai=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Sem resposta para um cliente que já desconectou
+	assert.Empty(t, w.Body.String())
+	assert.Len(t, fake.Requests(), 1)
+}
+
+// echoProvider devolve uma análise cujo resumo é o próprio prompt depois de delay e registra o máximo de
+// chamadas simultâneas; prompts em que block é true só terminam com o contexto
+type echoProvider struct {
+	delay       time.Duration
+	block       func(prompt string) bool
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (p *echoProvider) Name() string { return "echo" }
+
+func (p *echoProvider) Generate(ctx context.Context, req llm.Request) (string, error) {
+	current := p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+	for {
+		peak := p.maxInFlight.Load()
+		if current <= peak || p.maxInFlight.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	prompt := req.Messages[len(req.Messages)-1].Content
+	wait := time.After(p.delay)
+	if p.block != nil && p.block(prompt) {
+		wait = nil
+	}
+	select {
+	case <-wait:
+		return reportJSON(prompt), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestAnalyzeAllPlayersConcurrent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	names := []string{"João Silva", "Pedro Santos", "Carlos Lima", "Bruno Costa", "Rafael Souza", "Lucas Rocha"}
+	for _, name := range names {
+		db.Create(&models.Player{Name: name, Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 10, Tackles: 5, Passes: 100})
+	}
+
+	AnalyzeAllFanOut.Workers = 3
+	defer func() { AnalyzeAllFanOut.Workers = DefaultAnalyzeWorkers }()
+
+	provider := &echoProvider{delay: 30 * time.Millisecond}
+	router := gin.New()
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, provider))
+
+	req, _ := http.NewRequest("GET", "/analyze/players?ai=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		IndividualAnalyses []AnalysisResult `json:"individual_analyses"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Em paralelo, sem passar do limite, e na ordem dos jogadores
+	assert.Equal(t, int32(3), provider.maxInFlight.Load())
+	assert.Len(t, response.IndividualAnalyses, len(names))
+	for i, analysis := range response.IndividualAnalyses {
+		assert.Equal(t, uint(i+1), analysis.PlayerID)
+		assert.Equal(t, names[i], analysis.PlayerName)
+		assert.True(t, analysis.AIUsed)
+		assert.Contains(t, analysis.Analysis, names[i])
+	}
+}
+
+func TestAnalyzeAllPlayersPlayerTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 8, Tackles: 45, Passes: 350})
+
+	AnalyzeAllFanOut.PlayerTimeout = 50 * time.Millisecond
+	defer func() { AnalyzeAllFanOut.PlayerTimeout = 0 }()
+
+	// O LLM trava na análise individual de um dos jogadores
+	provider := &echoProvider{block: func(prompt string) bool {
+		return strings.Contains(prompt, "Pedro Santos") && !strings.Contains(prompt, "João Silva")
+	}}
+	router := gin.New()
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, provider))
+
+	analyze := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/analyze/players?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Só o jogador que estourou o prazo usa a análise estática
+	w := analyze()
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		IndividualAnalyses []AnalysisResult `json:"individual_analyses"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.IndividualAnalyses, 2)
+	assert.True(t, response.IndividualAnalyses[0].AIUsed)
+	assert.False(t, response.IndividualAnalyses[1].AIUsed)
+	assert.Equal(t, AIStatusTimeout, response.IndividualAnalyses[1].AIStatus.Status)
+
+	// Sem fallback no tempo limite, a requisição inteira responde 504
+	AIBehavior.FallbackOnTimeout = false
+	defer func() { AIBehavior.FallbackOnTimeout = true }()
+	assert.Equal(t, http.StatusGatewayTimeout, analyze().Code)
+}
+
+// repairBlockProvider responde à análise individual algo que não é JSON depois
+// de delay e trava no pedido de correção até o fim do contexto; a comparativa
+// responde na hora
+type repairBlockProvider struct {
+	delay time.Duration
+}
+
+func (p *repairBlockProvider) Name() string { return "repair-block" }
+
+func (p *repairBlockProvider) Generate(ctx context.Context, req llm.Request) (string, error) {
+	if req.Format == nil {
+		return "Comparativa", nil
+	}
+	wait := time.After(p.delay)
+	if len(req.Messages) > 1 {
+		wait = nil
+	}
+	select {
+	case <-wait:
+		return "sem JSON", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestAnalyzeAllPlayersPlayerTimeoutCoversRepairs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AnalyzeAllFanOut.PlayerTimeout = 200 * time.Millisecond
+	defer func() { AnalyzeAllFanOut.PlayerTimeout = 0 }()
+
+	router := gin.New()
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, &repairBlockProvider{delay: 150 * time.Millisecond}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players?ai=true", nil)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	router.ServeHTTP(w, req)
+	elapsed := time.Since(start)
+
+	// O pedido de correção usa o que sobrou do prazo do jogador, sem prazo próprio
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Less(t, elapsed, 300*time.Millisecond)
+	var response struct {
+		IndividualAnalyses []AnalysisResult `json:"individual_analyses"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.IndividualAnalyses, 1)
+	assert.False(t, response.IndividualAnalyses[0].AIUsed)
+	assert.Equal(t, AIStatusTimeout, response.IndividualAnalyses[0].AIStatus.Status)
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// CreateAppearance registra a atuação de um jogador em uma partida
+func CreateAppearance(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// Validação do ID
+		playerID, err := strconv.Atoi(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		var player models.Player
+		if err := db.First(&player, playerID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+			}
+			return
+		}
+
+		var appearance models.Appearance
+		if err := c.ShouldBindJSON(&appearance); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+			return
+		}
+		appearance.PlayerID = player.ID
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := keepPriorTotals(tx, player); err != nil {
+				return err
+			}
+			if err := tx.Create(&appearance).Error; err != nil {
+				return err
+			}
+			return syncPlayerTotals(tx, player.ID)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar atuação: " + err.Error()})
+			return
+		}
+
+		invalidateAnalysisCache(c.Request.Context(), player.ID)
+		c.JSON(http.StatusCreated, appearance)
+	}
+}
+
+// GetPlayerAppearances lista as atuações de um jogador, da mais recente para a mais antiga
+func GetPlayerAppearances(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		var player models.Player
+		if err := db.First(&player, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+			}
+			return
+		}
+
+		var appearances []models.Appearance
+		if err := db.Where("player_id = ?", player.ID).Order("match_date DESC").Find(&appearances).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar atuações: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, appearances)
+	}
+}
+
+// GetAppearanceByID retorna uma atuação específica
+func GetAppearanceByID(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appearance, ok := findAppearance(c, db)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, appearance)
+	}
+}
+
+// UpdateAppearance atualiza uma atuação e recalcula os agregados do jogador
+func UpdateAppearance(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appearance, ok := findAppearance(c, db)
+		if !ok {
+			return
+		}
+
+		var input models.Appearance
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+			return
+		}
+
+		// Select explícito para permitir zerar estatísticas
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&appearance).
+				Select("MatchDate", "Opponent", "Competition", "Minutes", "Goals", "Tackles", "Passes").
+				Updates(input).Error; err != nil {
+				return err
+			}
+			return syncPlayerTotals(tx, appearance.PlayerID)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar atuação: " + err.Error()})
+			return
+		}
+
+		invalidateAnalysisCache(c.Request.Context(), appearance.PlayerID)
+
+		// Busca a atuação atualizada
+		db.First(&appearance, appearance.ID)
+		c.JSON(http.StatusOK, appearance)
+	}
+}
+
+// DeleteAppearance remove uma atuação e recalcula os agregados do jogador
+func DeleteAppearance(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appearance, ok := findAppearance(c, db)
+		if !ok {
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(&appearance).Error; err != nil {
+				return err
+			}
+			return syncPlayerTotals(tx, appearance.PlayerID)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao deletar atuação: " + err.Error()})
+			return
+		}
+
+		invalidateAnalysisCache(c.Request.Context(), appearance.PlayerID)
+		c.JSON(http.StatusOK, gin.H{"message": "Atuação deletada com sucesso"})
+	}
+}
+
+// findAppearance busca a atuação do parâmetro :id, respondendo o erro quando não encontra
+func findAppearance(c *gin.Context, db *gorm.DB) (models.Appearance, bool) {
+	var appearance models.Appearance
+	id := c.Param("id")
+
+	// Validação do ID
+	if _, err := strconv.Atoi(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return appearance, false
+	}
+
+	if err := db.First(&appearance, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Atuação não encontrada"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar atuação: " + err.Error()})
+		}
+		return appearance, false
+	}
+
+	return appearance, true
+}
+
+// errTotalsFromAppearances indica uma alteração de gols, tackles ou passes
+// em um jogador cujos totais vêm das atuações
+var errTotalsFromAppearances = errors.New("gols, tackles e passes são calculados a partir das atuações registradas; altere as atuações do jogador")
+
+// keepPriorTotals guarda os totais do cadastro antes da primeira atuação do
+// jogador; depois disso eles só mudam pelas atuações
+func keepPriorTotals(tx *gorm.DB, player models.Player) error {
+	has, err := hasAppearances(tx, player.ID)
+	if err != nil || has {
+		return err
+	}
+	return tx.Model(&models.Player{}).Where("id = ?", player.ID).Updates(map[string]interface{}{
+		"prior_goals":   player.Goals,
+		"prior_tackles": player.Tackles,
+		"prior_passes":  player.Passes,
+	}).Error
+}
+
+// hasAppearances informa se o jogador tem atuações registradas
+func hasAppearances(db *gorm.DB, playerID uint) (bool, error) {
+	var count int64
+	err := db.Model(&models.Appearance{}).Where("player_id = ?", playerID).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// checkTotalsEditable responde 409 quando a alteração muda gols, tackles ou
+// passes de um jogador com atuações registradas
+func checkTotalsEditable(c *gin.Context, db *gorm.DB, player models.Player, changesTotals bool) bool {
+	if !changesTotals {
+		return true
+	}
+	has, err := hasAppearances(db, player.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar atuações: " + err.Error()})
+		return false
+	}
+	if has {
+		c.JSON(http.StatusConflict, gin.H{"error": "Não é possível alterar os totais: " + errTotalsFromAppearances.Error()})
+		return false
+	}
+	return true
+}
+
+// syncPlayerTotals recalcula gols, tackles e passes do jogador: os totais
+// anteriores à primeira atuação somados aos das atuações registradas
+func syncPlayerTotals(tx *gorm.DB, playerID uint) error {
+	var totals struct {
+		Goals   int
+		Tackles int
+		Passes  int
+	}
+
+	err := tx.Model(&models.Appearance{}).
+		Select("COALESCE(SUM(goals), 0) AS goals, COALESCE(SUM(tackles), 0) AS tackles, COALESCE(SUM(passes), 0) AS passes").
+		Where("player_id = ?", playerID).
+		Scan(&totals).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Player{}).Where("id = ?", playerID).Updates(map[string]interface{}{
+		"goals":   gorm.Expr("prior_goals + ?", totals.Goals),
+		"tackles": gorm.Expr("prior_tackles + ?", totals.Tackles),
+		"passes":  gorm.Expr("prior_passes + ?", totals.Passes),
+		"version": gorm.Expr("version + 1"),
+	}).Error
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAppearanceSyncsPlayerTotals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.POST("/players/:id/appearances", CreateAppearance(db))
+
+	player := models.Player{
+		Name:     "João Silva",
+		Age:      25,
+		Position: "Atacante",
+		Team:     "Flamengo",
+		Goals:    15,
+	}
+	db.Create(&player)
+
+	appearances := []models.Appearance{
+		{MatchDate: time.Date(2025, 5, 4, 16, 0, 0, 0, time.UTC), Opponent: "Palmeiras", Competition: "Brasileirão", Minutes: 90, Goals: 2, Tackles: 1, Passes: 30},
+		{MatchDate: time.Date(2025, 5, 11, 16, 0, 0, 0, time.UTC), Opponent: "Santos", Competition: "Brasileirão", Minutes: 45, Goals: 1, Passes: 12},
+	}
+
+	for _, appearance := range appearances {
+		jsonData, _ := json.Marshal(appearance)
+		req, _ := http.NewRequest("POST", "/players/1/appearances", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	// Os 15 gols do cadastro continuam somados às atuações
+	var updated models.Player
+	db.First(&updated, player.ID)
+	assert.Equal(t, 18, updated.Goals)
+	assert.Equal(t, 1, updated.Tackles)
+	assert.Equal(t, 42, updated.Passes)
+	assert.Equal(t, 15, updated.PriorGoals)
+}
+
+func TestPlayerTotalsReadOnlyWithAppearances(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.PUT("/players/:id", UpdatePlayer(db))
+	router.PATCH("/players/:id", PatchPlayer(db))
+	router.DELETE("/appearances/:id", DeleteAppearance(db))
+
+	player := models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15}
+	db.Create(&player)
+
+	send := func(method, path, contentType, body string) int {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Sem atuações, os totais são do cadastro
+	assert.Equal(t, http.StatusOK, send("PATCH", "/players/1", "application/merge-patch+json", `{"goals": 16}`))
+
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Now(), Opponent: "Santos", Minutes: 90, Goals: 2})
+	db.Model(&models.Player{}).Where("id = ?", player.ID).Update("prior_goals", 16)
+	syncPlayerTotals(db, player.ID)
+
+	put := `{"name": "João Silva", "age": 26, "position": "Atacante", "team": "Flamengo", "goals": 30}`
+	assert.Equal(t, http.StatusConflict, send("PUT", "/players/1", "application/json", put))
+	assert.Equal(t, http.StatusConflict, send("PATCH", "/players/1", "application/merge-patch+json", `{"goals": 30}`))
+	assert.Equal(t, http.StatusConflict, send("PATCH", "/players/1", "application/merge-patch+json", `{"goals": null, "age": 26}`))
+
+	// Os demais campos continuam editáveis, inclusive reenviando os totais atuais
+	put = `{"name": "João Silva", "age": 26, "position": "Atacante", "team": "Flamengo", "goals": 18}`
+	assert.Equal(t, http.StatusOK, send("PUT", "/players/1", "application/json", put))
+	assert.Equal(t, http.StatusOK, send("PATCH", "/players/1", "application/merge-patch+json", `{"age": 27, "goals": 18}`))
+
+	// Sem a última atuação, voltam os totais do cadastro
+	assert.Equal(t, http.StatusOK, send("DELETE", "/appearances/1", "", ""))
+	var updated models.Player
+	db.First(&updated, player.ID)
+	assert.Equal(t, 16, updated.Goals)
+	assert.Equal(t, 27, updated.Age)
+}
+
+func TestCreateAppearancePlayerNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.POST("/players/:id/appearances", CreateAppearance(db))
+
+	jsonData, _ := json.Marshal(models.Appearance{MatchDate: time.Now(), Opponent: "Santos", Minutes: 90})
+	req, _ := http.NewRequest("POST", "/players/999/appearances", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteAppearanceSyncsPlayerTotals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.DELETE("/appearances/:id", DeleteAppearance(db))
+
+	player := models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras"}
+	db.Create(&player)
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Now(), Opponent: "Santos", Minutes: 90, Goals: 1, Passes: 50})
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Now(), Opponent: "Grêmio", Minutes: 90, Passes: 40})
+	syncPlayerTotals(db, player.ID)
+
+	req, _ := http.NewRequest("DELETE", "/appearances/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updated models.Player
+	db.First(&updated, player.ID)
+	assert.Equal(t, 0, updated.Goals)
+	assert.Equal(t, 40, updated.Passes)
+}
+
+func TestCalculatePlayerStatsFromAppearances(t *testing.T) {
+	player := models.Player{
+		Name:     "João Silva",
+		Age:      25,
+		Position: "Atacante",
+		Team:     "Flamengo",
+		Goals:    3,
+		Passes:   42,
+		Appearances: []models.Appearance{
+			{Minutes: 90, Goals: 2, Passes: 30},
+			{Minutes: 45, Goals: 1, Passes: 12},
+		},
+	}
+
+	stats := calculatePlayerStats(player)
+
+	assert.False(t, stats.Stats.GamesEstimated)
+	assert.Equal(t, 2, stats.Stats.Games)
+	assert.Equal(t, 135, stats.Stats.MinutesPlayed)
+	assert.InDelta(t, 1.5, stats.Stats.GoalsPerGame, 0.001)
+	assert.InDelta(t, 2.0, stats.Stats.GoalsPer90, 0.001)
+	assert.InDelta(t, 28.0, stats.Stats.PassesPer90, 0.001)
+
+	assert.Nil(t, stats.PriorTotals)
+
+	// Totais anteriores às atuações não entram nas médias, na eficiência nem
+	// nos totais das estatísticas; aparecem à parte em PriorTotals
+	player.Goals, player.PriorGoals = 13, 10
+	stats = calculatePlayerStats(player)
+	assert.InDelta(t, 1.5, stats.Stats.GoalsPerGame, 0.001)
+	assert.Equal(t, 3, stats.Player.Goals)
+	assert.InDelta(t, calculatePlayerStats(models.Player{
+		Position: player.Position, Goals: 3, Passes: 42, Appearances: player.Appearances,
+	}).Stats.Efficiency, stats.Stats.Efficiency, 0.001)
+	assert.Equal(t, &StatTotals{Goals: 10}, stats.PriorTotals)
+
+	analysis := generatePlayerAnalysis(stats, i18n.Default)
+	assert.Contains(t, analysis.Analysis, "Em 2 jogos (135 minutos), marcou 3 gols")
+	assert.Contains(t, analysis.Analysis, "Antes das partidas registradas, somava 10 gols")
+}
@@ -0,0 +1,468 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+	"gorm.io/gorm"
+)
+
+// ChatConfig configura a memória das conversas com o assistente de scouting
+type ChatConfig struct {
+	// HistoryMessages é o número de mensagens anteriores reenviadas ao modelo
+	// a cada pergunta; as mais antigas continuam salvas, mas saem do contexto
+	HistoryMessages int
+	// MaxPlayers limita os jogadores enviados como contexto: os citados mais
+	// recentemente na conversa
+	MaxPlayers int
+	// AnalysesPerPlayer é o número de análises do histórico enviadas por jogador
+	AnalysesPerPlayer int
+}
+
+// ChatMemory configuração padrão
+var ChatMemory = ChatConfig{
+	HistoryMessages:   20,
+	MaxPlayers:        5,
+	AnalysesPerPlayer: 2,
+}
+
+// Limites, em caracteres, do título gerado pela primeira pergunta e de cada
+// análise anterior enviada como contexto
+const (
+	chatTitleLength       = 60
+	chatAnalysisMaxLength = 600
+)
+
+// ChatSessionRequest é o corpo de POST /chat/sessions
+type ChatSessionRequest struct {
+	// Title identifica a conversa; vazio usa o início da primeira pergunta
+	Title string `json:"title"`
+	// PlayerIDs são os jogadores em foco desde o início da conversa
+	PlayerIDs []uint `json:"player_ids"`
+	// Lang é o idioma das respostas (padrão: ?lang ou Accept-Language)
+	Lang string `json:"lang"`
+}
+
+// ChatMessageRequest é o corpo de POST /chat/sessions/:id/messages
+type ChatMessageRequest struct {
+	Content string `json:"content" binding:"required"`
+	// PlayerIDs acrescenta jogadores ao foco da conversa
+	PlayerIDs []uint `json:"player_ids"`
+}
+
+// ChatPlayer é um jogador enviado como contexto ao modelo
+type ChatPlayer struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ChatReply é a pergunta e a resposta salvas na conversa
+type ChatReply struct {
+	Question models.ChatMessage `json:"question"`
+	Answer   models.ChatMessage `json:"answer"`
+	// Players são os jogadores que o modelo recebeu como contexto
+	Players []ChatPlayer `json:"players"`
+}
+
+// CreateChatSession inicia uma conversa com o assistente de scouting
+func CreateChatSession(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		var request ChatSessionRequest
+		if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_data", err)})
+			return
+		}
+		if lang, ok = bodyLang(c, lang, request.Lang); !ok {
+			return
+		}
+
+		playerIDs, ok := resolveChatPlayers(c, db, request.PlayerIDs, lang)
+		if !ok {
+			return
+		}
+
+		session := models.ChatSession{
+			Title:     chatTitle(request.Title),
+			Lang:      lang,
+			PlayerIDs: playerIDs,
+		}
+		if err := db.Create(&session).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.create_chat", err)})
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/chat/sessions/%d", session.ID))
+		c.JSON(http.StatusCreated, session)
+	}
+}
+
+// ListChatSessions lista as conversas, das mais recentes para as mais antigas,
+// sem as mensagens
+func ListChatSessions(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		var sessions []models.ChatSession
+		if err := db.Order("updated_at DESC").Order("id DESC").Find(&sessions).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_chat", err)})
+			return
+		}
+		c.JSON(http.StatusOK, sessions)
+	}
+}
+
+// GetChatSession retorna a conversa com todas as mensagens
+func GetChatSession(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		session, ok := loadChatSession(c, db, lang, func(tx *gorm.DB) *gorm.DB {
+			return tx.Preload("Messages", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") })
+		})
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, session)
+	}
+}
+
+// DeleteChatSession remove (soft delete) a conversa e as mensagens
+func DeleteChatSession(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		session, ok := loadChatSession(c, db, lang, nil)
+		if !ok {
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("session_id = ?", session.ID).Delete(&models.ChatMessage{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&session).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.delete_chat", err)})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": i18n.T(lang, "chat.deleted")})
+	}
+}
+
+// SendChatMessage envia a pergunta ao modelo com o histórico da conversa e,
+// como contexto, os dados e as últimas análises dos jogadores em foco: os da
+// conversa, os informados em player_ids e os citados pelo nome na pergunta.
+// A pergunta e a resposta só são salvas quando o modelo responde; nas falhas
+// do modelo, a resposta traz o ai_status e o status HTTP da falha
+func SendChatMessage(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		var request ChatMessageRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_data", err)})
+			return
+		}
+		content := strings.TrimSpace(request.Content)
+		if content == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.empty_message")})
+			return
+		}
+
+		session, ok := loadChatSession(c, db, lang, nil)
+		if !ok {
+			return
+		}
+		// A conversa responde sempre no idioma em que foi criada
+		lang = session.Lang
+
+		requested, ok := resolveChatPlayers(c, db, request.PlayerIDs, lang)
+		if !ok {
+			return
+		}
+		mentioned, err := mentionedPlayers(db, content)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_players", err)})
+			return
+		}
+		focus := focusPlayers(session.PlayerIDs, append(requested, mentioned...))
+
+		prompt, players, err := createChatPrompt(db, focus, lang)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_chat", err)})
+			return
+		}
+
+		var history []models.ChatMessage
+		err = db.Where("session_id = ?", session.ID).Order("id DESC").Limit(max(ChatMemory.HistoryMessages, 0)).Find(&history).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_chat", err)})
+			return
+		}
+		slices.Reverse(history)
+
+		answer, err := generateChatAnswer(c.Request.Context(), provider, prompt, players, history, content)
+		if err != nil {
+			respondAIError(c, err, lang)
+			return
+		}
+
+		reply := ChatReply{Players: []ChatPlayer{}}
+		contextIDs := make([]uint, 0, len(players))
+		for _, player := range players {
+			contextIDs = append(contextIDs, player.ID)
+			reply.Players = append(reply.Players, ChatPlayer{ID: player.ID, Name: player.Name})
+		}
+		settings := llm.Describe(provider)
+		reply.Question = models.ChatMessage{SessionID: session.ID, Role: models.ChatRoleUser, Content: content}
+		reply.Answer = models.ChatMessage{
+			SessionID: session.ID,
+			Role:      models.ChatRoleAssistant,
+			Content:   answer,
+			PlayerIDs: contextIDs,
+			Provider:  settings.Provider,
+			ModelName: settings.Model,
+		}
+
+		session.PlayerIDs = focus
+		if session.Title == "" {
+			session.Title = chatTitle(content)
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&reply.Question).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&reply.Answer).Error; err != nil {
+				return err
+			}
+			// Save também atualiza updated_at, que ordena a lista de conversas
+			return tx.Save(&session).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.save_chat", err)})
+			return
+		}
+
+		c.JSON(http.StatusCreated, reply)
+	}
+}
+
+// loadChatSession valida o ID e busca a conversa; responde 400, 404 ou 500
+// quando não a encontra. scope permite carregar as mensagens junto
+func loadChatSession(c *gin.Context, db *gorm.DB, lang string, scope func(*gorm.DB) *gorm.DB) (models.ChatSession, bool) {
+	id := c.Param("id")
+
+	// Validação do ID
+	if _, err := strconv.Atoi(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_id")})
+		return models.ChatSession{}, false
+	}
+
+	query := db
+	if scope != nil {
+		query = scope(db)
+	}
+	var session models.ChatSession
+	if err := query.First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.chat_not_found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_chat", err)})
+		}
+		return models.ChatSession{}, false
+	}
+	return session, true
+}
+
+// resolveChatPlayers confere se os jogadores informados existem; responde 404
+// ou 500 quando não
+func resolveChatPlayers(c *gin.Context, db *gorm.DB, requested []uint, lang string) ([]uint, bool) {
+	if len(requested) == 0 {
+		return []uint{}, true
+	}
+
+	ids, err := resolveJobPlayers(db, requested)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.players_not_found")})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_players", err)})
+		return nil, false
+	}
+	return ids, true
+}
+
+// mentionedPlayers retorna os jogadores citados pelo nome completo no texto,
+// sem diferenciar maiúsculas nem acentos
+func mentionedPlayers(db *gorm.DB, text string) ([]uint, error) {
+	var players []models.Player
+	if err := db.Select("id", "name").Order("id").Find(&players).Error; err != nil {
+		return nil, err
+	}
+
+	normalized := " " + normalizeSearchText(text) + " "
+	var ids []uint
+	for _, player := range players {
+		name := normalizeSearchText(player.Name)
+		if name != "" && strings.Contains(normalized, " "+name+" ") {
+			ids = append(ids, player.ID)
+		}
+	}
+	return ids, nil
+}
+
+// focusPlayers acrescenta os jogadores citados ao foco da conversa: cada
+// jogador aparece uma vez, na posição da citação mais recente
+func focusPlayers(focus, cited []uint) []uint {
+	result := make([]uint, 0, len(focus)+len(cited))
+	for _, id := range focus {
+		if !slices.Contains(cited, id) {
+			result = append(result, id)
+		}
+	}
+	for _, id := range cited {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// chatPromptAnalysis é uma análise anterior no template chat_assistant
+type chatPromptAnalysis struct {
+	Date   string
+	Rating int
+	Text   string
+}
+
+// chatPromptPlayer é um jogador em foco no template chat_assistant
+type chatPromptPlayer struct {
+	comparativePromptPlayer
+	Games       string
+	Performance string
+	Analyses    []chatPromptAnalysis
+}
+
+// createChatPrompt renderiza as instruções do assistente no idioma lang com os
+// jogadores em foco mais recentes (até ChatMemory.MaxPlayers) e as últimas
+// análises de cada um. Jogadores removidos desde a citação são ignorados
+func createChatPrompt(db *gorm.DB, focus []uint, lang string) (prompts.Prompt, []models.Player, error) {
+	if limit := max(ChatMemory.MaxPlayers, 0); len(focus) > limit {
+		focus = focus[len(focus)-limit:]
+	}
+
+	var players []models.Player
+	if len(focus) > 0 {
+		if err := db.Preload("Appearances").Where("id IN ?", focus).Find(&players).Error; err != nil {
+			return prompts.Prompt{}, nil, err
+		}
+	}
+	slices.SortFunc(players, func(a, b models.Player) int {
+		return slices.Index(focus, a.ID) - slices.Index(focus, b.ID)
+	})
+
+	data := struct{ Players []chatPromptPlayer }{}
+	for i, player := range players {
+		stats := calculatePlayerStats(player)
+		safe, _ := promptPlayer(lang, player)
+		entry := chatPromptPlayer{
+			comparativePromptPlayer: comparativePromptPlayer{
+				Number:     i + 1,
+				Name:       safe.Name,
+				Position:   safe.Position,
+				Team:       safe.Team,
+				Age:        player.Age,
+				Goals:      stats.Player.Goals,
+				Tackles:    stats.Player.Tackles,
+				Passes:     stats.Player.Passes,
+				Efficiency: stats.Stats.Efficiency,
+			},
+			Games:       describeGames(stats, lang),
+			Performance: labelText(lang, stats.Stats.PerformanceRank),
+		}
+
+		var analyses []models.Analysis
+		err := db.Where("player_id = ?", player.ID).Order("id DESC").Limit(max(ChatMemory.AnalysesPerPlayer, 0)).Find(&analyses).Error
+		if err != nil {
+			return prompts.Prompt{}, nil, err
+		}
+		for _, analysis := range analyses {
+			entry.Analyses = append(entry.Analyses, chatPromptAnalysis{
+				Date:   analysis.CreatedAt.Format("2006-01-02"),
+				Rating: analysis.Rating,
+				Text:   promptText(lang, analysis.Text, chatAnalysisMaxLength),
+			})
+		}
+		data.Players = append(data.Players, entry)
+	}
+
+	prompt, err := AIPrompts.RenderLocale(prompts.ChatAssistant, lang, data)
+	return prompt, players, err
+}
+
+// generateChatAnswer envia ao modelo as instruções, o histórico e a nova
+// pergunta; respostas vazias ou que mostram que o prompt foi desviado são
+// recusadas
+func generateChatAnswer(ctx context.Context, provider llm.Provider, prompt prompts.Prompt, players []models.Player, history []models.ChatMessage, question string) (string, error) {
+	if provider == nil {
+		return "", errNoProvider
+	}
+
+	messages := []llm.Message{{Role: llm.RoleSystem, Content: prompt.Text}}
+	for _, message := range history {
+		messages = append(messages, llm.Message{Role: message.Role, Content: message.Content})
+	}
+	messages = append(messages, llm.Message{Role: llm.RoleUser, Content: question})
+
+	answer, err := provider.Generate(ctx, llm.Request{Messages: messages})
+	if err != nil {
+		return "", fmt.Errorf("erro ao chamar %s: %w", provider.Name(), err)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", fmt.Errorf("%w: resposta vazia", llm.ErrInvalidOutput)
+	}
+	if looksHijacked(answer, players...) {
+		return "", fmt.Errorf("%w: a resposta contém instruções em vez de responder ao scout", llm.ErrInvalidOutput)
+	}
+	return answer, nil
+}
+
+// chatTitle limpa o título e o limita a chatTitleLength caracteres
+func chatTitle(text string) string {
+	title := strings.Join(strings.Fields(text), " ")
+	if runes := []rune(title); len(runes) > chatTitleLength {
+		title = strings.TrimSpace(string(runes[:chatTitleLength])) + "…"
+	}
+	return title
+}
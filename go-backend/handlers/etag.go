@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// ConcurrencyConfig configuração do controle de concorrência otimista
+type ConcurrencyConfig struct {
+	// RequireIfMatch exige o header If-Match em PUT, PATCH e DELETE de jogadores
+	RequireIfMatch bool
+}
+
+// PlayerConcurrency configuração padrão
+var PlayerConcurrency = ConcurrencyConfig{
+	RequireIfMatch: false,
+}
+
+// errVersionConflict indica que o jogador foi alterado por outra requisição
+var errVersionConflict = errors.New("jogador alterado por outra requisição")
+
+// playerETag gera o ETag do jogador a partir do ID e da versão
+func playerETag(player models.Player) string {
+	return fmt.Sprintf(`"%d-%d"`, player.ID, player.Version)
+}
+
+// etagMatches verifica se algum ETag da lista do header corresponde ao atual.
+// Com weak (If-None-Match), ETags fracos ("W/...") comparam pelo valor; sem
+// ele (If-Match), a comparação é forte e um ETag fraco nunca corresponde (RFC 9110)
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch valida o header If-Match contra a versão atual do jogador,
+// respondendo 428 quando ele é obrigatório e está ausente, ou 412 quando não confere
+func checkIfMatch(c *gin.Context, player models.Player) bool {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		if PlayerConcurrency.RequireIfMatch {
+			c.JSON(http.StatusPreconditionRequired, gin.H{"error": "Header If-Match é obrigatório"})
+			return false
+		}
+		return true
+	}
+
+	if !etagMatches(ifMatch, playerETag(player), false) {
+		c.Header("ETag", playerETag(player))
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Jogador foi alterado por outra requisição"})
+		return false
+	}
+	return true
+}
+
+// bumpPlayerVersion incrementa a versão do jogador somente se ela ainda for a
+// lida anteriormente; deve rodar na mesma transação da alteração. Também vale
+// para jogadores deletados, que podem ser restaurados
+func bumpPlayerVersion(tx *gorm.DB, player models.Player) error {
+	result := tx.Unscoped().Model(&models.Player{}).
+		Where("id = ? AND version = ?", player.ID, player.Version).
+		UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errVersionConflict
+	}
+	return nil
+}
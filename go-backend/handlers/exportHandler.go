@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatXLSX   = "xlsx"
+	ExportFormatNDJSON = "ndjson"
+
+	// exportBatchSize é quantos jogadores são lidos do banco por vez durante a exportação
+	exportBatchSize = 200
+)
+
+// exportContentTypes mapeia o formato de exportação para o Content-Type da resposta
+var exportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv; charset=utf-8",
+	ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportFormatNDJSON: "application/x-ndjson",
+}
+
+// exportRecord reúne os dados de um jogador disponíveis para as colunas exportadas
+type exportRecord struct {
+	stats    PlayerStats
+	rating   int
+	analysis AnalysisResult
+}
+
+// exportColumn define uma coluna exportada: o cabeçalho (também chave no NDJSON) e o valor
+type exportColumn struct {
+	header string
+	value  func(record exportRecord) interface{}
+}
+
+// playerExportColumns são as colunas do jogador com os campos calculados de PlayerStats
+var playerExportColumns = []exportColumn{
+	{"id", func(r exportRecord) interface{} { return r.stats.Player.ID }},
+	{"name", func(r exportRecord) interface{} { return r.stats.Player.Name }},
+	{"age", func(r exportRecord) interface{} { return r.stats.Player.Age }},
+	{"position", func(r exportRecord) interface{} { return r.stats.Player.Position }},
+	{"team", func(r exportRecord) interface{} { return r.stats.Player.Team }},
+	{"goals", func(r exportRecord) interface{} { return r.stats.Player.Goals }},
+	{"tackles", func(r exportRecord) interface{} { return r.stats.Player.Tackles }},
+	{"passes", func(r exportRecord) interface{} { return r.stats.Player.Passes }},
+	{"games", func(r exportRecord) interface{} { return r.stats.Stats.Games }},
+	{"games_estimated", func(r exportRecord) interface{} { return r.stats.Stats.GamesEstimated }},
+	{"minutes_played", func(r exportRecord) interface{} { return r.stats.Stats.MinutesPlayed }},
+	{"goals_per_game", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.GoalsPerGame) }},
+	{"tackles_per_game", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.TacklesPerGame) }},
+	{"passes_per_game", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.PassesPerGame) }},
+	{"goals_per_90", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.GoalsPer90) }},
+	{"tackles_per_90", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.TacklesPer90) }},
+	{"passes_per_90", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.PassesPer90) }},
+	{"efficiency", func(r exportRecord) interface{} { return roundStat(r.stats.Stats.Efficiency) }},
+	{"performance_rank", func(r exportRecord) interface{} { return r.stats.Stats.PerformanceRank }},
+	{"rating", func(r exportRecord) interface{} { return r.rating }},
+	{"updated_at", func(r exportRecord) interface{} { return r.stats.Player.UpdatedAt.Format(time.RFC3339) }},
+}
+
+// analysisExportColumns acrescentam às colunas do jogador o resultado da análise
+var analysisExportColumns = append(append([]exportColumn{}, playerExportColumns...),
+	exportColumn{"analysis", func(r exportRecord) interface{} { return r.analysis.Analysis }},
+	exportColumn{"insights", func(r exportRecord) interface{} { return strings.Join(r.analysis.Insights, " | ") }},
+	exportColumn{"ai_used", func(r exportRecord) interface{} { return r.analysis.AIUsed }},
+)
+
+// ExportPlayers exporta os jogadores em CSV, XLSX ou NDJSON, com os mesmos
+// filtros e ordenação da listagem, inclusive include_deleted
+func ExportPlayers(db *gorm.DB) gin.HandlerFunc {
+	return exportHandler(db, "players", playerExportColumns, true, func(player models.Player, _ string) exportRecord {
+		stats := calculatePlayerStats(player)
+		return exportRecord{stats: stats, rating: calculateRating(stats.Player, stats)}
+	})
+}
+
+// ExportAnalyses exporta a análise estática de cada jogador em CSV, XLSX ou
+// NDJSON, com os textos no idioma de ?lang ou Accept-Language; jogadores
+// deletados não são analisados, então include_deleted é recusado
+func ExportAnalyses(db *gorm.DB) gin.HandlerFunc {
+	return exportHandler(db, "analyses", analysisExportColumns, false, func(player models.Player, lang string) exportRecord {
+		stats := calculatePlayerStats(player)
+		analysis := generatePlayerAnalysis(stats, lang)
+		return exportRecord{stats: stats, rating: analysis.Rating, analysis: analysis}
+	})
+}
+
+// exportHandler monta o handler de exportação: valida formato e filtros e
+// escreve as linhas à medida que os lotes de jogadores são lidos do banco.
+// allowDeleted indica se o filtro include_deleted é aceito
+func exportHandler(db *gorm.DB, name string, columns []exportColumn, allowDeleted bool, build func(models.Player, string) exportRecord) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		format := strings.ToLower(c.DefaultQuery("format", ExportFormatCSV))
+		contentType, ok := exportContentTypes[format]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_export_format")})
+			return
+		}
+
+		filter, err := parsePlayerFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_params", err)})
+			return
+		}
+		if filter.IncludeDeleted && !allowDeleted {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.export_include_deleted")})
+			return
+		}
+
+		filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		c.Status(http.StatusOK)
+
+		writer := newExportWriter(format, c.Writer, columns)
+
+		// A exportação ignora a paginação: todos os jogadores filtrados são
+		// exportados, em lotes com offset (FindInBatches só respeita a ordem por ID)
+		base := db.Model(&models.Player{})
+		if filter.IncludeDeleted {
+			base = base.Unscoped()
+		}
+		query := filter.sorted(filter.apply(base)).Preload("Appearances")
+		for offset := 0; err == nil; offset += exportBatchSize {
+			var batch []models.Player
+			if err = query.Offset(offset).Limit(exportBatchSize).Find(&batch).Error; err != nil {
+				break
+			}
+
+			for _, player := range batch {
+				if err = writer.writeRecord(build(player, lang)); err != nil {
+					break
+				}
+			}
+			c.Writer.Flush()
+
+			if len(batch) < exportBatchSize {
+				break
+			}
+		}
+		if err == nil {
+			err = writer.close()
+		}
+
+		// O status já foi enviado; resta registrar o erro e interromper a resposta
+		if err != nil {
+			log.Printf("Erro ao exportar %s: %v", name, err)
+			c.Abort()
+		}
+	}
+}
+
+// exportWriter escreve registros em um formato de exportação
+type exportWriter interface {
+	writeRecord(record exportRecord) error
+	close() error
+}
+
+// newExportWriter cria o writer do formato informado
+func newExportWriter(format string, w io.Writer, columns []exportColumn) exportWriter {
+	switch format {
+	case ExportFormatXLSX:
+		return &xlsxExportWriter{w: w, columns: columns}
+	case ExportFormatNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w), columns: columns}
+	default:
+		return &csvExportWriter{w: csv.NewWriter(w), columns: columns}
+	}
+}
+
+// formulaPrefixes são os caracteres iniciais que fazem uma planilha tratar o
+// texto da célula como fórmula
+const formulaPrefixes = "=+-@\t\r"
+
+// spreadsheetCell protege os textos do cadastro contra injeção de fórmulas no
+// CSV e no XLSX: um texto que começa como fórmula ganha um apóstrofo na frente.
+// Números e os demais valores não mudam
+func spreadsheetCell(value interface{}) interface{} {
+	if text, ok := value.(string); ok && text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return value
+}
+
+// csvExportWriter escreve CSV com cabeçalho
+type csvExportWriter struct {
+	w             *csv.Writer
+	columns       []exportColumn
+	headerWritten bool
+}
+
+func (e *csvExportWriter) writeRecord(record exportRecord) error {
+	if !e.headerWritten {
+		if err := e.w.Write(exportHeaders(e.columns)); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = fmt.Sprint(spreadsheetCell(column.value(record)))
+	}
+	if err := e.w.Write(row); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) close() error {
+	// Mesmo sem jogadores, o arquivo exportado tem o cabeçalho
+	if !e.headerWritten {
+		if err := e.w.Write(exportHeaders(e.columns)); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonExportWriter escreve um objeto JSON por linha
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+	columns []exportColumn
+}
+
+func (e *ndjsonExportWriter) writeRecord(record exportRecord) error {
+	object := make(map[string]interface{}, len(e.columns))
+	for _, column := range e.columns {
+		object[column.header] = column.value(record)
+	}
+	return e.encoder.Encode(object)
+}
+
+func (e *ndjsonExportWriter) close() error {
+	return nil
+}
+
+// xlsxExportWriter monta a planilha com o StreamWriter do excelize, que mantém
+// pouca memória por linha; o arquivo em si só pode ser enviado ao final
+type xlsxExportWriter struct {
+	w       io.Writer
+	columns []exportColumn
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	row     int
+}
+
+func (e *xlsxExportWriter) start() error {
+	e.file = excelize.NewFile()
+	stream, err := e.file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return err
+	}
+	e.stream = stream
+
+	headers := exportHeaders(e.columns)
+	row := make([]interface{}, len(headers))
+	for i, header := range headers {
+		row[i] = header
+	}
+	e.row = 1
+	return e.stream.SetRow("A1", row)
+}
+
+func (e *xlsxExportWriter) writeRecord(record exportRecord) error {
+	if e.stream == nil {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	row := make([]interface{}, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = spreadsheetCell(column.value(record))
+	}
+
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, row)
+}
+
+func (e *xlsxExportWriter) close() error {
+	if e.stream == nil {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	defer e.file.Close()
+
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.w)
+}
+
+// exportHeaders retorna os cabeçalhos das colunas
+func exportHeaders(columns []exportColumn) []string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	return headers
+}
+
+// roundStat arredonda estatísticas calculadas para duas casas decimais
+func roundStat(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+func setupExportData(db *gorm.DB) {
+	players := []models.Player{
+		{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120},
+		{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 8, Tackles: 45, Passes: 350},
+		{Name: "Lucas Lima", Age: 19, Position: "Atacante", Team: "Santos", Goals: 22, Tackles: 2, Passes: 60},
+	}
+	for _, player := range players {
+		db.Create(&player)
+	}
+}
+
+func TestExportPlayersCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupExportData(db)
+
+	router := gin.New()
+	router.GET("/players/export", ExportPlayers(db))
+
+	req, _ := http.NewRequest("GET", "/players/export?format=csv&position=Atacante&sort=goals&order=desc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	assert.NoError(t, err)
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, "name", records[0][1])
+		assert.Contains(t, records[0], "efficiency")
+		assert.Contains(t, records[0], "rating")
+		assert.Equal(t, "Lucas Lima", records[1][1])
+		assert.Equal(t, "João Silva", records[2][1])
+	}
+}
+
+func TestExportAnalysesNDJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupExportData(db)
+
+	router := gin.New()
+	router.GET("/analyze/players/export", ExportAnalyses(db))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/export?format=ndjson", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "João Silva", lines[0]["name"])
+		assert.NotEmpty(t, lines[0]["analysis"])
+		assert.NotEmpty(t, lines[0]["performance_rank"])
+		assert.Equal(t, 0.5, lines[0]["goals_per_game"])
+	}
+}
+
+func TestExportPlayersXLSX(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupExportData(db)
+
+	router := gin.New()
+	router.GET("/players/export", ExportPlayers(db))
+
+	req, _ := http.NewRequest("GET", "/players/export?format=xlsx&team=palmeiras", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	file, err := excelize.OpenReader(bytes.NewReader(w.Body.Bytes()))
+	if assert.NoError(t, err) {
+		rows, err := file.GetRows("Sheet1")
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 2) {
+			assert.Equal(t, "id", rows[0][0])
+			assert.Equal(t, "Pedro Santos", rows[1][1])
+		}
+	}
+}
+
+func TestExportEscapesFormulas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "=HYPERLINK(\"http://exemplo.com\")", Age: 25, Position: "Atacante", Team: "@SUM(A1:A9)"})
+
+	router := gin.New()
+	router.GET("/players/export", ExportPlayers(db))
+
+	export := func(format string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/players/export?format="+format, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// CSV e XLSX: textos que começam como fórmula ganham um apóstrofo
+	records, err := csv.NewReader(export("csv").Body).ReadAll()
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, `'=HYPERLINK("http://exemplo.com")`, records[1][1])
+		assert.Contains(t, records[1], "'@SUM(A1:A9)")
+	}
+
+	file, err := excelize.OpenReader(bytes.NewReader(export("xlsx").Body.Bytes()))
+	if assert.NoError(t, err) {
+		rows, err := file.GetRows("Sheet1")
+		assert.NoError(t, err)
+		if assert.Len(t, rows, 2) {
+			assert.Equal(t, `'=HYPERLINK("http://exemplo.com")`, rows[1][1])
+			assert.Contains(t, rows[1], "'@SUM(A1:A9)")
+		}
+	}
+
+	// NDJSON não é aberto como planilha e mantém o texto original
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(export("ndjson").Body.Bytes(), &line))
+	assert.Equal(t, `=HYPERLINK("http://exemplo.com")`, line["name"])
+}
+
+func TestExportPlayersInvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players/export", ExportPlayers(db))
+
+	req, _ := http.NewRequest("GET", "/players/export?format=pdf", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportIncludeDeleted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupExportData(db)
+	db.Delete(&models.Player{}, 2)
+
+	router := gin.New()
+	router.GET("/players/export", ExportPlayers(db))
+	router.GET("/analyze/players/export", ExportAnalyses(db))
+
+	export := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := export("/players/export?format=csv")
+	records, _ := csv.NewReader(w.Body).ReadAll()
+	assert.Len(t, records, 3)
+
+	w = export("/players/export?format=csv&include_deleted=true")
+	assert.Equal(t, http.StatusOK, w.Code)
+	records, _ = csv.NewReader(w.Body).ReadAll()
+	if assert.Len(t, records, 4) {
+		assert.Equal(t, "Pedro Santos", records[2][1])
+	}
+
+	// A análise não cobre jogadores deletados
+	w = export("/analyze/players/export?include_deleted=true")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "include_deleted")
+}
+
+func TestExportAnalysesLocalizedErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/analyze/players/export", ExportAnalyses(db))
+
+	cases := map[string]string{
+		"/analyze/players/export?lang=en&format=pdf":              "Invalid export format: use csv, xlsx or ndjson",
+		"/analyze/players/export?lang=es&include_deleted=true":    "Parámetros inválidos: include_deleted no se acepta en esta exportación",
+		"/analyze/players/export?lang=en&page=0&sort=nonexistent": "Invalid parameters: ",
+	}
+	for path, expected := range cases {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		var response map[string]string
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Contains(t, response["error"], expected, path)
+	}
+}
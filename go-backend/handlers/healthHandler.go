@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"gorm.io/gorm"
+)
+
+// Estados do health check
+const (
+	healthOK          = "ok"
+	healthDegraded    = "degraded"    // a API funciona, mas as análises com IA usam o fallback
+	healthUnavailable = "unavailable" // o banco não responde
+)
+
+// Health informa o estado da API, do banco e do provedor de LLM, incluindo o
+// circuit breaker. Responde 503 apenas quando o banco está fora do ar
+func Health(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := healthOK
+		code := http.StatusOK
+
+		database := gin.H{"status": healthOK}
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.PingContext(c.Request.Context())
+		}
+		if err != nil {
+			database = gin.H{"status": healthUnavailable, "error": err.Error()}
+			status = healthUnavailable
+			code = http.StatusServiceUnavailable
+		}
+
+		llmHealth := gin.H{"status": healthUnavailable}
+		if provider != nil {
+			llmHealth = gin.H{"status": healthOK, "provider": provider.Name()}
+			if breaker, ok := llm.Breaker(provider); ok {
+				breakerStatus := breaker.Status()
+				llmHealth["circuit_breaker"] = breakerStatus
+				if breakerStatus.State != llm.BreakerClosed {
+					llmHealth["status"] = healthDegraded
+				}
+			}
+		}
+		if llmHealth["status"] != healthOK && status == healthOK {
+			status = healthDegraded
+		}
+
+		c.JSON(code, gin.H{
+			"status":   status,
+			"database": database,
+			"llm":      llmHealth,
+		})
+	}
+}
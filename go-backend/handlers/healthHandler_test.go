@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	breaker := llm.NewCircuitBreaker(llm.BreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+	provider := llm.WithBreaker(&llm.Fake{Err: errors.New("connection refused")}, breaker)
+
+	router := gin.New()
+	router.GET("/health", Health(db, provider))
+
+	health := func() map[string]interface{} {
+		req, _ := http.NewRequest("GET", "/health", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	response := health()
+	assert.Equal(t, "ok", response["status"])
+	llmHealth := response["llm"].(map[string]interface{})
+	assert.Equal(t, "fake", llmHealth["provider"])
+	assert.Equal(t, "closed", llmHealth["circuit_breaker"].(map[string]interface{})["state"])
+
+	provider.Generate(context.Background(), llm.Prompt("Analise"))
+
+	response = health()
+	assert.Equal(t, "degraded", response["status"])
+	assert.Equal(t, "ok", response["database"].(map[string]interface{})["status"])
+	breakerStatus := response["llm"].(map[string]interface{})["circuit_breaker"].(map[string]interface{})
+	assert.Equal(t, "open", breakerStatus["state"])
+	assert.NotEmpty(t, breakerStatus["retry_at"])
+}
+
+func TestAnalyzeAllPlayersCircuitOpen(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	for _, name := range []string{"João Silva", "Pedro Santos", "Lucas Lima", "Rafael Costa"} {
+		db.Create(&models.Player{Name: name, Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 10, Passes: 100})
+	}
+
+	fake := &llm.Fake{Err: errors.New("connection refused")}
+	breaker := llm.NewCircuitBreaker(llm.BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+
+	router := gin.New()
+	router.GET("/analyze/players", AnalyzeAllPlayers(db, llm.WithBreaker(fake, breaker)))
+
+	req, _ := http.NewRequest("GET", "/analyze/players?ai=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Depois de duas falhas o circuito abre e os demais jogadores usam a análise estática
+	assert.Len(t, fake.Requests(), 2)
+
+	var response struct {
+		IndividualAnalyses []AnalysisResult `json:"individual_analyses"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.IndividualAnalyses, 4)
+	for _, analysis := range response.IndividualAnalyses {
+		assert.False(t, analysis.AIUsed)
+	}
+}
@@ -0,0 +1,442 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	// maxImportBytes limita o tamanho do arquivo enviado ao endpoint de importação
+	maxImportBytes = 10 << 20
+)
+
+// Status de cada linha da importação
+const (
+	ImportRowCreated   = "created"
+	ImportRowUpdated   = "updated"
+	ImportRowUnchanged = "unchanged"
+	ImportRowRejected  = "rejected"
+)
+
+// ImportOptions opções da importação de jogadores
+type ImportOptions struct {
+	Format string // csv ou ndjson
+	DryRun bool   // valida e simula sem gravar
+	Atomic bool   // desfaz tudo se alguma linha for rejeitada
+}
+
+// ImportRowResult representa o resultado de uma linha do arquivo
+type ImportRowResult struct {
+	Row      int      `json:"row"`
+	Status   string   `json:"status"`
+	PlayerID uint     `json:"player_id,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Team     string   `json:"team,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// ImportReport representa o relatório da importação
+type ImportReport struct {
+	DryRun     bool              `json:"dry_run"`
+	Committed  bool              `json:"committed"`
+	Total      int               `json:"total"`
+	Created    int               `json:"created"`
+	Updated    int               `json:"updated"`
+	Unchanged  int               `json:"unchanged"`
+	Rejected   int               `json:"rejected"`
+	RowResults []ImportRowResult `json:"rows"`
+}
+
+// importRow é uma linha já convertida em jogador, ou com os erros de conversão
+type importRow struct {
+	number int
+	player models.Player
+	errors []string
+}
+
+// importColumnAliases aceita cabeçalhos em inglês e português
+var importColumnAliases = map[string]string{
+	"name": "name", "nome": "name",
+	"age": "age", "idade": "age",
+	"position": "position", "posicao": "position",
+	"team": "team", "time": "team", "clube": "team",
+	"goals": "goals", "gols": "goals",
+	"tackles": "tackles", "desarmes": "tackles",
+	"passes": "passes",
+}
+
+// errImportRolledBack desfaz a transação em dry-run ou importação atômica com rejeições
+var errImportRolledBack = errors.New("importação desfeita")
+
+// ImportPlayers importa jogadores de um arquivo CSV ou NDJSON (corpo da
+// requisição ou campo "file" de um formulário multipart)
+func ImportPlayers(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+		options := ImportOptions{
+			Format: c.Query("format"),
+			DryRun: c.Query("dry_run") == "true" || c.Query("dry_run") == "1",
+			Atomic: c.Query("atomic") == "true" || c.Query("atomic") == "1",
+		}
+
+		var reader io.Reader = c.Request.Body
+		if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+			file, header, err := c.Request.FormFile("file")
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado no campo file"})
+				return
+			}
+			defer file.Close()
+
+			reader = file
+			if options.Format == "" {
+				options.Format = ImportFormatFromFilename(header.Filename)
+			}
+		} else if options.Format == "" {
+			options.Format = importFormatFromContentType(c.ContentType())
+		}
+
+		if options.Format == "" {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Formato não identificado: use text/csv, application/x-ndjson ou o parâmetro format"})
+			return
+		}
+
+		report, err := RunPlayerImport(db, reader, options)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Arquivo excede o tamanho máximo de importação"})
+			} else {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao importar jogadores: " + err.Error()})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// RunPlayerImport lê, valida e grava os jogadores em uma única transação.
+// Jogadores com o mesmo nome e time (sem diferenciar maiúsculas) são atualizados
+func RunPlayerImport(db *gorm.DB, reader io.Reader, options ImportOptions) (ImportReport, error) {
+	report := ImportReport{DryRun: options.DryRun}
+
+	var rows []importRow
+	var err error
+	switch options.Format {
+	case ImportFormatCSV:
+		rows, err = parseCSVImport(reader)
+	case ImportFormatNDJSON, "jsonl":
+		rows, err = parseNDJSONImport(reader)
+	default:
+		return report, fmt.Errorf("formato não suportado: %s", options.Format)
+	}
+	if err != nil {
+		return report, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			result, err := importPlayerRow(tx, row)
+			if err != nil {
+				return fmt.Errorf("linha %d: %w", row.number, err)
+			}
+			report.add(result)
+		}
+
+		if options.DryRun || (options.Atomic && report.Rejected > 0) {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return report, err
+	}
+
+	report.Committed = err == nil
+	if !report.Committed {
+		// IDs de jogadores criados em uma transação desfeita não existem
+		for i := range report.RowResults {
+			if report.RowResults[i].Status == ImportRowCreated {
+				report.RowResults[i].PlayerID = 0
+			}
+		}
+	} else if report.Created+report.Updated > 0 {
+		var changed []uint
+		for _, result := range report.RowResults {
+			if result.Status == ImportRowCreated || result.Status == ImportRowUpdated {
+				changed = append(changed, result.PlayerID)
+			}
+		}
+		invalidateAnalysisCache(context.Background(), changed...)
+	}
+
+	return report, nil
+}
+
+// add contabiliza o resultado de uma linha
+func (r *ImportReport) add(result ImportRowResult) {
+	r.Total++
+	switch result.Status {
+	case ImportRowCreated:
+		r.Created++
+	case ImportRowUpdated:
+		r.Updated++
+	case ImportRowUnchanged:
+		r.Unchanged++
+	case ImportRowRejected:
+		r.Rejected++
+	}
+	r.RowResults = append(r.RowResults, result)
+}
+
+// importPlayerRow valida a linha e cria ou atualiza o jogador correspondente
+func importPlayerRow(tx *gorm.DB, row importRow) (ImportRowResult, error) {
+	player := row.player
+	result := ImportRowResult{Row: row.number, Name: player.Name, Team: player.Team}
+
+	errs := row.errors
+	if len(errs) == 0 {
+		if err := validateImportedPlayer(player); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		result.Status = ImportRowRejected
+		result.Errors = errs
+		return result, nil
+	}
+
+	var existing models.Player
+	err := tx.Where("LOWER(name) = LOWER(?) AND LOWER(team) = LOWER(?)", player.Name, player.Team).
+		Order("id").
+		First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Create(&player).Error; err != nil {
+			return result, err
+		}
+		result.Status = ImportRowCreated
+		result.PlayerID = player.ID
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.PlayerID = existing.ID
+	if existing.Name == player.Name && existing.Team == player.Team && existing.Age == player.Age &&
+		existing.Position == player.Position && existing.Goals == player.Goals &&
+		existing.Tackles == player.Tackles && existing.Passes == player.Passes {
+		result.Status = ImportRowUnchanged
+		return result, nil
+	}
+
+	if existing.Goals != player.Goals || existing.Tackles != player.Tackles || existing.Passes != player.Passes {
+		has, err := hasAppearances(tx, existing.ID)
+		if err != nil {
+			return result, err
+		}
+		if has {
+			result.Status = ImportRowRejected
+			result.Errors = []string{errTotalsFromAppearances.Error()}
+			return result, nil
+		}
+	}
+
+	// A linha é o estado completo do jogador, então valores zero também são gravados
+	err = tx.Model(&existing).Updates(map[string]interface{}{
+		"name":     player.Name,
+		"team":     player.Team,
+		"age":      player.Age,
+		"position": player.Position,
+		"goals":    player.Goals,
+		"tackles":  player.Tackles,
+		"passes":   player.Passes,
+		"version":  gorm.Expr("version + 1"),
+	}).Error
+	if err != nil {
+		return result, err
+	}
+
+	result.Status = ImportRowUpdated
+	return result, nil
+}
+
+// validateImportedPlayer aplica as regras de binding do modelo e as mesmas
+// validações básicas do POST /players
+func validateImportedPlayer(player models.Player) error {
+	if err := binding.Validator.ValidateStruct(&player); err != nil {
+		return err
+	}
+	return validatePlayer(player)
+}
+
+// parseCSVImport lê um CSV com cabeçalho; o separador pode ser vírgula ou
+// ponto e vírgula (padrão do Excel em português)
+func parseCSVImport(reader io.Reader) ([]importRow, error) {
+	buffered := bufio.NewReader(reader)
+	firstLine, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if newline := bytes.IndexByte(firstLine, '\n'); newline >= 0 {
+		firstLine = firstLine[:newline]
+	}
+
+	csvReader := csv.NewReader(buffered)
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		csvReader.Comma = ';'
+	}
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("arquivo vazio")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cabeçalho inválido: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		// normalizeSearchText também descarta o BOM que o Excel coloca no início
+		name = normalizeSearchText(name)
+		if column, ok := importColumnAliases[name]; ok {
+			columns[column] = i
+		}
+	}
+	for _, required := range []string{"name", "age", "position", "team"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("coluna obrigatória ausente no cabeçalho: %s", required)
+		}
+	}
+
+	var rows []importRow
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := importRow{number: line}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			row.errors = append(row.errors, parseErr.Err.Error())
+			rows = append(rows, row)
+			continue
+		}
+
+		value := func(column string) string {
+			if index, ok := columns[column]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+
+		row.player.Name = value("name")
+		row.player.Position = value("position")
+		row.player.Team = value("team")
+		numericColumns := []struct {
+			name   string
+			target *int
+		}{
+			{"age", &row.player.Age},
+			{"goals", &row.player.Goals},
+			{"tackles", &row.player.Tackles},
+			{"passes", &row.player.Passes},
+		}
+		for _, column := range numericColumns {
+			raw := value(column.name)
+			if raw == "" {
+				continue
+			}
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				row.errors = append(row.errors, fmt.Sprintf("valor inválido para %s: %q", column.name, raw))
+				continue
+			}
+			*column.target = parsed
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseNDJSONImport lê um jogador JSON por linha, ignorando linhas em branco
+func parseNDJSONImport(reader io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := importRow{number: line}
+		if err := json.Unmarshal(text, &row.player); err != nil {
+			row.errors = append(row.errors, "JSON inválido: "+err.Error())
+		}
+
+		// Estes campos são controlados pelo servidor
+		row.player.Model = gorm.Model{}
+		row.player.Version = 0
+		row.player.Appearances = nil
+
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
+
+// ImportFormatFromFilename identifica o formato pela extensão do arquivo
+func ImportFormatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// importFormatFromContentType identifica o formato pelo Content-Type da requisição
+func importFormatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return ImportFormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportPlayersCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.POST("/players/import", ImportPlayers(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15})
+
+	csvData := "name,age,position,team,goals,tackles,passes\n" +
+		"joão silva,26,Atacante,FLAMENGO,0,5,120\n" +
+		"Pedro Santos,28,Meio-campo,Palmeiras,8,45,350\n" +
+		",30,Zagueiro,Santos,1,80,150\n" +
+		"Carlos Oliveira,abc,Zagueiro,São Paulo,2,120,180\n"
+
+	req, _ := http.NewRequest("POST", "/players/import", strings.NewReader(csvData))
+	req.Header.Set("Content-Type", "text/csv")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report ImportReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+	assert.True(t, report.Committed)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 2, report.Rejected)
+	assert.Equal(t, ImportRowUpdated, report.RowResults[0].Status)
+	assert.Equal(t, uint(1), report.RowResults[0].PlayerID)
+	assert.Equal(t, 4, report.RowResults[2].Row)
+	assert.Equal(t, ImportRowRejected, report.RowResults[3].Status)
+
+	var updated models.Player
+	db.First(&updated, 1)
+	assert.Equal(t, 26, updated.Age)
+	assert.Equal(t, 0, updated.Goals)
+	assert.Equal(t, 2, updated.Version)
+
+	var count int64
+	db.Model(&models.Player{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestImportPlayersSemicolonPortugueseHeaders(t *testing.T) {
+	db := setupTestDB()
+
+	csvData := "Nome;Idade;Posição;Time;Gols\nPedro Santos;28;Meio-campo;Palmeiras;8\n"
+
+	report, err := RunPlayerImport(db, strings.NewReader(csvData), ImportOptions{Format: ImportFormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+
+	var player models.Player
+	db.First(&player)
+	assert.Equal(t, "Palmeiras", player.Team)
+	assert.Equal(t, 8, player.Goals)
+}
+
+func TestImportPlayersTotalsFromAppearances(t *testing.T) {
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 3})
+	db.Create(&models.Appearance{PlayerID: 1, MatchDate: time.Now(), Opponent: "Santos", Minutes: 90, Goals: 3})
+
+	// Outros gols: a linha é recusada, porque os totais vêm das atuações
+	csvData := "name,age,position,team,goals\nJoão Silva,26,Atacante,Flamengo,20\n"
+	report, err := RunPlayerImport(db, strings.NewReader(csvData), ImportOptions{Format: ImportFormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Rejected)
+	assert.Equal(t, []string{errTotalsFromAppearances.Error()}, report.RowResults[0].Errors)
+
+	// Mesmos totais: os demais campos são atualizados
+	csvData = "name,age,position,team,goals\nJoão Silva,26,Atacante,Flamengo,3\n"
+	report, err = RunPlayerImport(db, strings.NewReader(csvData), ImportOptions{Format: ImportFormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+}
+
+func TestImportPlayersNDJSONDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.POST("/players/import", ImportPlayers(db))
+
+	var body bytes.Buffer
+	body.WriteString(`{"name":"João Silva","age":25,"position":"Atacante","team":"Flamengo","goals":15}` + "\n")
+	body.WriteString("\n")
+	body.WriteString(`{"name":"João Silva","age":25,"position":"Atacante","team":"Flamengo","goals":16}` + "\n")
+	body.WriteString(`{"name":"Sem Idade","position":"Goleiro","team":"Santos"}` + "\n")
+	body.WriteString(`{"name": quebrado` + "\n")
+
+	req, _ := http.NewRequest("POST", "/players/import?dry_run=true", &body)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report ImportReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 2, report.Rejected)
+	assert.Equal(t, 3, report.RowResults[1].Row)
+	assert.Equal(t, uint(0), report.RowResults[0].PlayerID)
+
+	var count int64
+	db.Model(&models.Player{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestImportPlayersAtomic(t *testing.T) {
+	db := setupTestDB()
+
+	csvData := "name,age,position,team\nPedro Santos,28,Meio-campo,Palmeiras\nSem Time,20,Goleiro,\n"
+
+	report, err := RunPlayerImport(db, strings.NewReader(csvData), ImportOptions{Format: ImportFormatCSV, Atomic: true})
+	assert.NoError(t, err)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 1, report.Rejected)
+
+	var count int64
+	db.Model(&models.Player{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestImportPlayersUnknownFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.POST("/players/import", ImportPlayers(db))
+
+	req, _ := http.NewRequest("POST", "/players/import", strings.NewReader("qualquer coisa"))
+	req.Header.Set("Content-Type", "text/plain")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
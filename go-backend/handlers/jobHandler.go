@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// AnalysisJobRequest é o corpo de POST /analyze/jobs
+type AnalysisJobRequest struct {
+	// Jogadores do lote; vazio analisa todos
+	PlayerIDs []uint `json:"player_ids"`
+	// AI usa o provedor de LLM (padrão: true)
+	AI *bool `json:"ai"`
+	// StrictAI registra a falha da IA como erro do jogador, em vez do fallback estático
+	StrictAI bool `json:"strict_ai"`
+	// Lang é o idioma das análises (padrão: ?lang ou Accept-Language)
+	Lang string `json:"lang"`
+}
+
+// AnalysisJobResponse é o lote com o percentual concluído
+type AnalysisJobResponse struct {
+	models.AnalysisJob
+	Progress float64 `json:"progress"` // 0-100
+}
+
+// CreateAnalysisJob enfileira um lote de análises processado em segundo plano
+// pelo JobRunner; o andamento é consultado em GET /analyze/jobs/:id
+func CreateAnalysisJob(db *gorm.DB, runner *JobRunner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		var request AnalysisJobRequest
+		if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_data", err)})
+			return
+		}
+		if lang, ok = bodyLang(c, lang, request.Lang); !ok {
+			return
+		}
+
+		playerIDs, err := resolveJobPlayers(db, request.PlayerIDs)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.players_not_found")})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_players", err)})
+			return
+		}
+		if len(playerIDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.no_players_to_analyze")})
+			return
+		}
+
+		job := models.AnalysisJob{
+			Status:   models.JobPending,
+			AI:       request.AI == nil || *request.AI || request.StrictAI,
+			StrictAI: request.StrictAI,
+			Lang:     lang,
+			Total:    len(playerIDs),
+		}
+		for _, id := range playerIDs {
+			job.Items = append(job.Items, models.AnalysisJobItem{PlayerID: id, Status: models.JobItemPending})
+		}
+
+		if err := db.Create(&job).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.create_job", err)})
+			return
+		}
+		runner.notify()
+
+		// Os itens são consultados no GET; a resposta traz apenas o lote
+		job.Items = nil
+		c.Header("Location", fmt.Sprintf("/analyze/jobs/%d", job.ID))
+		c.JSON(http.StatusAccepted, newAnalysisJobResponse(job))
+	}
+}
+
+// GetAnalysisJob retorna o andamento do lote e, para cada jogador, a situação,
+// o erro ou a análise já gerada
+func GetAnalysisJob(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_id")})
+			return
+		}
+
+		var job models.AnalysisJob
+		err := db.Preload("Items", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+			Preload("Items.Analysis").
+			First(&job, id).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.job_not_found")})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_job", err)})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, newAnalysisJobResponse(job))
+	}
+}
+
+// resolveJobPlayers retorna os IDs do lote, sem repetições; sem IDs, todos os
+// jogadores. Retorna gorm.ErrRecordNotFound se algum jogador não existir
+func resolveJobPlayers(db *gorm.DB, requested []uint) ([]uint, error) {
+	var ids []uint
+	if len(requested) == 0 {
+		err := db.Model(&models.Player{}).Order("id").Pluck("id", &ids).Error
+		return ids, err
+	}
+
+	seen := make(map[uint]bool, len(requested))
+	for _, id := range requested {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var count int64
+	if err := db.Model(&models.Player{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(ids) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return ids, nil
+}
+
+// newAnalysisJobResponse calcula o percentual concluído do lote
+func newAnalysisJobResponse(job models.AnalysisJob) AnalysisJobResponse {
+	response := AnalysisJobResponse{AnalysisJob: job}
+	if job.Total > 0 {
+		response.Progress = roundStat(float64(job.Processed) * 100 / float64(job.Total))
+	}
+	return response
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupJobTest prepara banco, jogadores e o JobRunner em execução; o banco
+// sqlite em memória precisa de uma única conexão para ser compartilhado
+func setupJobTest(t *testing.T, provider llm.Provider) (*gorm.DB, *gin.Engine, *JobRunner) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 5, Tackles: 20, Passes: 350})
+	db.Create(&models.Player{Name: "Carlos Lima", Age: 30, Position: "Zagueiro", Team: "Santos", Goals: 1, Tackles: 40, Passes: 200})
+
+	runner := NewJobRunner(db, provider, 2)
+	router := gin.New()
+	router.POST("/analyze/jobs", CreateAnalysisJob(db, runner))
+	router.GET("/analyze/jobs/:id", GetAnalysisJob(db))
+	return db, router, runner
+}
+
+// waitForJob consulta o lote até ele terminar
+func waitForJob(t *testing.T, router *gin.Engine, id uint) AnalysisJobResponse {
+	var job AnalysisJobResponse
+	assert.Eventually(t, func() bool {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/analyze/jobs/%d", id), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		json.Unmarshal(w.Body.Bytes(), &job)
+		return job.Status == models.JobCompleted || job.Status == models.JobFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func createJob(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/analyze/jobs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAnalysisJob(t *testing.T) {
+	fake := &llm.Fake{}
+	db, router, runner := setupJobTest(t, fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, runner.Start(ctx))
+
+	w := createJob(router, "")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var created AnalysisJobResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	assert.Equal(t, 3, created.Total)
+	assert.True(t, created.AI)
+	assert.Equal(t, fmt.Sprintf("/analyze/jobs/%d", created.ID), w.Header().Get("Location"))
+
+	job := waitForJob(t, router, created.ID)
+	assert.Equal(t, models.JobCompleted, job.Status)
+	assert.Equal(t, 3, job.Processed)
+	assert.Zero(t, job.Failed)
+	assert.Equal(t, 100.0, job.Progress)
+	assert.NotNil(t, job.FinishedAt)
+	if assert.Len(t, job.Items, 3) {
+		for _, item := range job.Items {
+			assert.Equal(t, models.JobItemDone, item.Status)
+			if assert.NotNil(t, item.Analysis) {
+				assert.True(t, item.Analysis.AIUsed)
+				assert.Equal(t, item.PlayerID, item.Analysis.PlayerID)
+			}
+		}
+	}
+	assert.Len(t, fake.Requests(), 3)
+
+	// As análises do lote entram no histórico
+	var count int64
+	db.Model(&models.Analysis{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestAnalysisJobRetriesUnsavedItems(t *testing.T) {
+	db, router, runner := setupJobTest(t, &llm.Fake{})
+
+	// A primeira tentativa de marcar um jogador como em análise falha
+	var failed atomic.Bool
+	db.Callback().Update().Before("gorm:update").Register("test:fail_item_start", func(tx *gorm.DB) {
+		updates, ok := tx.Statement.Dest.(map[string]interface{})
+		if ok && tx.Statement.Table == "analysis_job_items" && updates["status"] == models.JobItemRunning && failed.CompareAndSwap(false, true) {
+			tx.AddError(errors.New("banco indisponível"))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, runner.Start(ctx))
+
+	var created AnalysisJobResponse
+	json.Unmarshal(createJob(router, "").Body.Bytes(), &created)
+
+	// O jogador volta para a fila em vez de o lote terminar sem ele; na
+	// próxima passada, ele é analisado e o lote termina
+	assert.Eventually(t, failed.Load, 5*time.Second, 10*time.Millisecond)
+	runner.notify()
+	response := waitForJob(t, router, created.ID)
+	assert.Equal(t, models.JobCompleted, response.Status)
+	assert.Equal(t, 3, response.Processed)
+	assert.Zero(t, response.Failed)
+	for _, item := range response.Items {
+		assert.Equal(t, models.JobItemDone, item.Status)
+	}
+}
+
+func TestAnalysisJobGivesUpOnUnsavedItems(t *testing.T) {
+	db, router, runner := setupJobTest(t, &llm.Fake{})
+
+	// O andamento de um dos jogadores nunca é gravado
+	var saves atomic.Int32
+	db.Callback().Update().Before("gorm:update").Register("test:fail_item_save", func(tx *gorm.DB) {
+		item, ok := tx.Statement.Model.(*models.AnalysisJobItem)
+		updates, _ := tx.Statement.Dest.(map[string]interface{})
+		if ok && item.PlayerID == 2 && updates["status"] == models.JobItemDone {
+			saves.Add(1)
+			tx.AddError(errors.New("banco indisponível"))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, runner.Start(ctx))
+
+	var created AnalysisJobResponse
+	json.Unmarshal(createJob(router, "").Body.Bytes(), &created)
+
+	// Depois de maxJobItemAttempts passadas, o jogador e o lote falham em vez
+	// de o lote ficar em execução para sempre
+	for attempt := int32(1); attempt <= maxJobItemAttempts; attempt++ {
+		assert.Eventually(t, func() bool { return saves.Load() >= attempt }, 5*time.Second, 10*time.Millisecond)
+		runner.notify()
+	}
+	response := waitForJob(t, router, created.ID)
+	assert.Equal(t, models.JobFailed, response.Status)
+	assert.Contains(t, response.Error, "jogador 2")
+	assert.Equal(t, 3, response.Processed)
+	assert.Equal(t, 1, response.Failed)
+	for _, item := range response.Items {
+		if item.PlayerID == 2 {
+			assert.Equal(t, models.JobItemFailed, item.Status)
+			assert.Equal(t, maxJobItemAttempts, item.Attempts)
+			assert.Nil(t, item.AnalysisID)
+		} else {
+			assert.Equal(t, models.JobItemDone, item.Status)
+			assert.Equal(t, 1, item.Attempts)
+		}
+	}
+
+	// A análise gravada em cada tentativa é desfeita com o andamento
+	var count int64
+	db.Model(&models.Analysis{}).Where("player_id = ?", 2).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestAnalysisJobPlayerErrors(t *testing.T) {
+	_, router, runner := setupJobTest(t, &llm.Fake{Err: errors.New("conexão recusada")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.Start(ctx)
+
+	// Com strict_ai, a falha da IA vira erro de cada jogador
+	w := createJob(router, `{"player_ids": [1, 2, 2], "strict_ai": true}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var created AnalysisJobResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	assert.Equal(t, 2, created.Total)
+
+	job := waitForJob(t, router, created.ID)
+	assert.Equal(t, models.JobCompleted, job.Status)
+	assert.Equal(t, 2, job.Failed)
+	for _, item := range job.Items {
+		assert.Equal(t, models.JobItemFailed, item.Status)
+		assert.Contains(t, item.Error, "conexão recusada")
+		assert.Nil(t, item.Analysis)
+	}
+
+	// Sem strict_ai, a análise estática é usada
+	json.Unmarshal(createJob(router, `{"player_ids": [3]}`).Body.Bytes(), &created)
+	job = waitForJob(t, router, created.ID)
+	if assert.Len(t, job.Items, 1) && assert.NotNil(t, job.Items[0].Analysis) {
+		assert.False(t, job.Items[0].Analysis.AIUsed)
+		assert.Equal(t, AIStatusUnavailable, job.Items[0].Analysis.AIStatus)
+	}
+}
+
+func TestCreateAnalysisJobValidation(t *testing.T) {
+	db, router, _ := setupJobTest(t, &llm.Fake{})
+
+	assert.Equal(t, http.StatusNotFound, createJob(router, `{"player_ids": [1, 999]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, createJob(router, `{"player_ids": "1"}`).Code)
+
+	db.Where("1 = 1").Delete(&models.Player{})
+	assert.Equal(t, http.StatusBadRequest, createJob(router, `{}`).Code)
+
+	req, _ := http.NewRequest("GET", "/analyze/jobs/999", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAnalysisJobResumesAfterRestart(t *testing.T) {
+	fake := &llm.Fake{}
+	db, router, runner := setupJobTest(t, fake)
+
+	// Lote interrompido: um jogador já analisado e outro em análise quando o servidor parou
+	started := time.Now()
+	job := models.AnalysisJob{Status: models.JobRunning, AI: true, Total: 2, Processed: 1, StartedAt: &started, Items: []models.AnalysisJobItem{
+		{PlayerID: 1, Status: models.JobItemDone},
+		{PlayerID: 2, Status: models.JobItemRunning},
+	}}
+	db.Create(&job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, runner.Start(ctx))
+
+	resumed := waitForJob(t, router, job.ID)
+	assert.Equal(t, models.JobCompleted, resumed.Status)
+	assert.Equal(t, 2, resumed.Processed)
+	assert.Equal(t, models.JobItemDone, resumed.Items[1].Status)
+	assert.Len(t, fake.Requests(), 1)
+}
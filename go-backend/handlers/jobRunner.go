@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultJobWorkers é quantos jogadores de um lote são analisados ao mesmo tempo
+	DefaultJobWorkers = 4
+
+	// jobPollInterval é o intervalo em que a fila é consultada sem novos lotes
+	// notificados (ex.: depois de uma falha do banco)
+	jobPollInterval = 30 * time.Second
+
+	// maxJobItemAttempts é quantas passadas um jogador tem para gravar o
+	// andamento antes de o jogador e o lote serem dados como falhos
+	maxJobItemAttempts = 3
+)
+
+// errJobItemAttempts indica um jogador que esgotou maxJobItemAttempts
+var errJobItemAttempts = errors.New("andamento não gravado após várias tentativas")
+
+// JobRunner processa os lotes de análise em segundo plano, um lote por vez, com
+// até workers jogadores em paralelo. A fila é o próprio banco: lotes pendentes
+// ou interrompidos por um reinício são retomados em Start
+type JobRunner struct {
+	db       *gorm.DB
+	provider llm.Provider
+	workers  int
+	wake     chan struct{}
+}
+
+// NewJobRunner cria o processador de lotes; workers <= 0 usa DefaultJobWorkers
+func NewJobRunner(db *gorm.DB, provider llm.Provider, workers int) *JobRunner {
+	if workers <= 0 {
+		workers = DefaultJobWorkers
+	}
+	return &JobRunner{
+		db:       db,
+		provider: provider,
+		workers:  workers,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Start devolve à fila os jogadores que estavam em análise quando o servidor
+// parou e processa os lotes até ctx ser cancelado. O processamento começa
+// mesmo quando a devolução falha; o erro é apenas retornado
+func (r *JobRunner) Start(ctx context.Context) error {
+	err := r.db.Model(&models.AnalysisJobItem{}).
+		Where("status = ?", models.JobItemRunning).
+		Update("status", models.JobItemPending).Error
+
+	go r.run(ctx)
+	r.notify()
+	return err
+}
+
+// notify avisa que há um novo lote na fila
+func (r *JobRunner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run processa os lotes pendentes em ordem de criação
+func (r *JobRunner) run(ctx context.Context) {
+	for {
+		var job models.AnalysisJob
+		err := r.db.WithContext(ctx).
+			Where("status IN ?", []string{models.JobPending, models.JobRunning}).
+			Order("id").First(&job).Error
+		if err == nil {
+			err = r.process(ctx, job)
+			if err == nil {
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Erro ao processar lotes de análise: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+// process analisa os jogadores pendentes do lote e o encerra. Se o andamento
+// de algum jogador não puder ser gravado, o lote continua em execução e é
+// retomado na próxima passada da fila; depois de maxJobItemAttempts passadas,
+// o jogador e o lote são encerrados como falhos
+func (r *JobRunner) process(ctx context.Context, job models.AnalysisJob) error {
+	updates := map[string]interface{}{"status": models.JobRunning}
+	if job.StartedAt == nil {
+		updates["started_at"] = time.Now()
+	}
+	if err := r.db.Model(&job).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	// Jogadores que ficaram em análise numa passada anterior que falhou
+	// voltam para a fila
+	err := r.db.Model(&models.AnalysisJobItem{}).
+		Where("job_id = ? AND status = ?", job.ID, models.JobItemRunning).
+		Update("status", models.JobItemPending).Error
+	if err != nil {
+		return err
+	}
+
+	var items []models.AnalysisJobItem
+	if err := r.db.Where("job_id = ? AND status = ?", job.ID, models.JobItemPending).Order("id").Find(&items).Error; err != nil {
+		return r.finish(job.ID, models.JobFailed, "Erro ao buscar jogadores do lote: "+err.Error())
+	}
+
+	queue := make(chan models.AnalysisJobItem)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for i := 0; i < min(r.workers, len(items)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if err := r.processItem(ctx, job, item); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+enqueue:
+	for _, item := range items {
+		select {
+		case queue <- item:
+		case <-ctx.Done():
+			break enqueue
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	// Interrompido: o lote continua de onde parou no próximo Start
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err = errors.Join(errs...)
+	if errors.Is(err, errJobItemAttempts) {
+		return r.finish(job.ID, models.JobFailed, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("lote de análise %d: %w", job.ID, err)
+	}
+	return r.finish(job.ID, models.JobCompleted, "")
+}
+
+// processItem analisa um jogador e grava, numa mesma transação, a análise no
+// histórico, o resultado no item e o progresso do lote. Falhas da análise ficam
+// no item; o erro retornado indica que o andamento não pôde ser gravado e o
+// jogador precisa ser processado de novo, ou errJobItemAttempts quando ele
+// esgotou as tentativas e foi dado como falho
+func (r *JobRunner) processItem(ctx context.Context, job models.AnalysisJob, item models.AnalysisJobItem) error {
+	if item.Attempts >= maxJobItemAttempts {
+		err := fmt.Errorf("jogador %d: %w", item.PlayerID, errJobItemAttempts)
+		if saveErr := r.saveItem(job, item, map[string]interface{}{"status": models.JobItemFailed, "error": err.Error()}, nil); saveErr != nil {
+			return fmt.Errorf("erro ao registrar jogador %d: %w", item.PlayerID, saveErr)
+		}
+		return err
+	}
+
+	err := r.db.Model(&item).Updates(map[string]interface{}{
+		"status":   models.JobItemRunning,
+		"attempts": gorm.Expr("attempts + 1"),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar jogador %d: %w", item.PlayerID, err)
+	}
+
+	analysis, err := r.analyze(ctx, job, item.PlayerID)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	updates := map[string]interface{}{"status": models.JobItemDone}
+	if err != nil {
+		updates = map[string]interface{}{"status": models.JobItemFailed, "error": err.Error()}
+		analysis = nil
+	}
+	if err := r.saveItem(job, item, updates, analysis); err != nil {
+		return fmt.Errorf("erro ao registrar jogador %d: %w", item.PlayerID, err)
+	}
+	return nil
+}
+
+// jobAnalysis é a análise de um jogador do lote ainda não gravada no histórico
+type jobAnalysis struct {
+	stats  PlayerStats
+	result AnalysisResult
+}
+
+// saveItem grava na mesma transação a análise (se houver), o item com updates e
+// o progresso do lote; um item falho conta também em failed
+func (r *JobRunner) saveItem(job models.AnalysisJob, item models.AnalysisJobItem, updates map[string]interface{}, analysis *jobAnalysis) error {
+	failed := 0
+	if updates["status"] == models.JobItemFailed {
+		failed = 1
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if analysis != nil {
+			if err := saveAnalysis(tx, analysis.stats, &analysis.result, r.provider); err != nil {
+				return fmt.Errorf("erro ao registrar análise: %w", err)
+			}
+			updates["analysis_id"] = analysis.result.AnalysisID
+		}
+		if err := tx.Model(&item).Updates(updates).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.AnalysisJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"processed": gorm.Expr("processed + 1"),
+			"failed":    gorm.Expr("failed + ?", failed),
+		}).Error
+	})
+}
+
+// analyze gera a análise do jogador, sem gravá-la no histórico
+func (r *JobRunner) analyze(ctx context.Context, job models.AnalysisJob, playerID uint) (*jobAnalysis, error) {
+	var player models.Player
+	if err := r.db.WithContext(ctx).Preload("Appearances").First(&player, playerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("jogador não encontrado")
+		}
+		return nil, fmt.Errorf("erro ao buscar jogador: %w", err)
+	}
+
+	// Lotes criados antes da escolha de idioma usam o padrão
+	lang := job.Lang
+	if lang == "" {
+		lang = i18n.Default
+	}
+
+	stats := calculatePlayerStats(player)
+	result := generatePlayerAnalysis(stats, lang)
+	if job.AI {
+		var err error
+		options := aiOptions{Enabled: true, Strict: job.StrictAI, Lang: lang}
+		if result, err = generatePlayerAnalysisWithAI(ctx, r.provider, player, stats, options); err != nil {
+			return nil, fmt.Errorf("%s: %w", newAIStatus(err, lang).Message, err)
+		}
+	}
+	return &jobAnalysis{stats: stats, result: result}, nil
+}
+
+// finish encerra o lote com a situação final
+func (r *JobRunner) finish(jobID uint, status, message string) error {
+	return r.db.Model(&models.AnalysisJob{}).Where("id = ?", jobID).Updates(map[string]interface{}{
+		"status":      status,
+		"error":       message,
+		"finished_at": time.Now(),
+	}).Error
+}
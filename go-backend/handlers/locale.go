@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+)
+
+// Classificações de performance e tendências como ficam nos dados (histórico,
+// exportação e cache); as respostas as traduzem para o idioma pedido
+const (
+	rankExcellent    = "Excelente"
+	rankVeryGood     = "Muito Bom"
+	rankGood         = "Bom"
+	rankAverage      = "Regular"
+	rankBelowAverage = "Abaixo da Média"
+
+	trendImproving = "evolução"
+	trendStable    = "estável"
+	trendDeclining = "queda"
+)
+
+// labelKeys são as chaves de tradução das classificações e tendências
+var labelKeys = map[string]string{
+	rankExcellent:    "rank.excellent",
+	rankVeryGood:     "rank.very_good",
+	rankGood:         "rank.good",
+	rankAverage:      "rank.average",
+	rankBelowAverage: "rank.below_average",
+	trendImproving:   "trend.improving",
+	trendStable:      "trend.stable",
+	trendDeclining:   "trend.declining",
+}
+
+// positionKeys são as chaves de tradução das posições cadastradas
+var positionKeys = map[string]string{
+	"atacante":      "position.forward",
+	"meio-campo":    "position.midfielder",
+	"meio-campista": "position.midfielder",
+	"zagueiro":      "position.defender",
+	"goleiro":       "position.goalkeeper",
+}
+
+// requestLang escolhe o idioma da resposta: ?lang tem precedência sobre o
+// header Accept-Language. Responde 400 quando ?lang não é suportado
+func requestLang(c *gin.Context) (string, bool) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	if param := c.Query("lang"); param != "" {
+		parsed, ok := i18n.Parse(param)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.unsupported_lang", param, strings.Join(i18n.Supported, ", "))})
+			return "", false
+		}
+		lang = parsed
+	}
+
+	c.Header("Content-Language", lang)
+	return lang, true
+}
+
+// bodyLang aplica o idioma informado no corpo da requisição, que tem
+// precedência sobre ?lang e Accept-Language. Responde 400 quando não é suportado
+func bodyLang(c *gin.Context, lang, requested string) (string, bool) {
+	if requested == "" {
+		return lang, true
+	}
+	parsed, ok := i18n.Parse(requested)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.unsupported_lang", requested, strings.Join(i18n.Supported, ", "))})
+		return "", false
+	}
+
+	c.Header("Content-Language", parsed)
+	return parsed, true
+}
+
+// labelText traduz uma classificação de performance ou tendência
+func labelText(lang, label string) string {
+	if key, ok := labelKeys[label]; ok {
+		return i18n.T(lang, key)
+	}
+	return label
+}
+
+// positionText traduz a posição do jogador; posições desconhecidas ficam como cadastradas
+func positionText(lang, position string) string {
+	if key, ok := positionKeys[strings.ToLower(position)]; ok && lang != i18n.PT {
+		return i18n.T(lang, key)
+	}
+	return position
+}
+
+// localizedTrend retorna uma cópia da tendência com a direção traduzida
+func localizedTrend(lang string, trend *SeasonTrend) *SeasonTrend {
+	if trend == nil {
+		return nil
+	}
+	localized := *trend
+	localized.Direction = labelText(lang, trend.Direction)
+	return &localized
+}
+
+// localizedStats retorna as estatísticas com a classificação e a tendência traduzidas
+func localizedStats(lang string, stats PlayerStats) PlayerStats {
+	stats.Stats.PerformanceRank = labelText(lang, stats.Stats.PerformanceRank)
+	stats.Trend = localizedTrend(lang, stats.Trend)
+	return stats
+}
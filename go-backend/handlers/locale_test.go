@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/cache"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzePlayerLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 21, Position: "Atacante", Team: "Flamengo", Goals: 18, Tackles: 5, Passes: 120})
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{Err: errors.New("connection refused")}))
+
+	analyze := func(path, acceptLanguage string) (*httptest.ResponseRecorder, AnalysisResult) {
+		req, _ := http.NewRequest("GET", path, nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var result AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return w, result
+	}
+
+	// Sem preferência, o português continua o padrão
+	w, result := analyze("/analyze/players/1", "")
+	assert.Equal(t, "pt-BR", w.Header().Get("Content-Language"))
+	assert.Contains(t, result.Analysis, "21 anos, atua como Atacante")
+	assert.Contains(t, result.Insights, "Jogador jovem com potencial de desenvolvimento")
+
+	w, result = analyze("/analyze/players/1?lang=en", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	assert.Equal(t, "en", result.Lang)
+	assert.Contains(t, result.Analysis, "21 years old, plays as Forward for Flamengo")
+	assert.Contains(t, result.Insights, "Young player with development potential")
+	assert.Contains(t, result.Insights, "Efficient goalscorer, excellent finishing")
+
+	// Accept-Language escolhe o idioma suportado de maior preferência
+	_, result = analyze("/analyze/players/1", "fr-FR, es-AR;q=0.9, en;q=0.5")
+	assert.Equal(t, "es", result.Lang)
+	assert.Contains(t, result.Analysis, "21 años, juega como Delantero")
+
+	// ?lang tem precedência sobre o header
+	_, result = analyze("/analyze/players/1?lang=pt", "en")
+	assert.Equal(t, "pt-BR", result.Lang)
+
+	// A falha da IA também é descrita no idioma pedido
+	_, result = analyze("/analyze/players/1?ai=true&lang=en", "")
+	assert.False(t, result.AIUsed)
+	if assert.NotNil(t, result.AIStatus) {
+		assert.Equal(t, AIStatusUnavailable, result.AIStatus.Status)
+		assert.Equal(t, "AI service unavailable", result.AIStatus.Message)
+	}
+
+	// Erros seguem o idioma
+	w, _ = analyze("/analyze/players/99?lang=es", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "Jugador no encontrado")
+
+	w, _ = analyze("/analyze/players/1?lang=fr", "en")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Unsupported language: fr")
+}
+
+func TestAnalyzePlayerLocalizedPrompt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AICache.Store = cache.NewLRU(10)
+	defer func() { AICache.Store = nil }()
+
+	fake := &llm.Fake{Response: reportJSON("João Silva is a clinical forward")}
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+
+	analyze := func(lang string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true&lang="+lang, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, analyze("en").Code)
+	if assert.Len(t, fake.Requests(), 1) {
+		prompt := fake.Requests()[0].Messages[0].Content
+		assert.Contains(t, prompt, "detailed analysis in English")
+		assert.Contains(t, prompt, "- Position: «Forward»")
+		assert.Contains(t, prompt, "ATTACKING ANALYSIS")
+		assert.Contains(t, prompt, "- Performance: Average")
+	}
+
+	// Cada idioma tem a própria entrada no cache
+	assert.Equal(t, "HIT", analyze("en").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", analyze("es").Header().Get("X-Cache"))
+	if assert.Len(t, fake.Requests(), 2) {
+		assert.Contains(t, fake.Requests()[1].Messages[0].Content, "análisis detallado en español")
+	}
+
+	var record models.Analysis
+	db.Last(&record)
+	assert.Equal(t, "es", record.Lang)
+}
+
+func TestCompareSeasonsLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupSeasonData(db)
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/seasons", CompareSeasons(db))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/seasons?lang=en", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Seasons []SeasonSummary `json:"seasons"`
+		Trends  []SeasonTrend   `json:"trends"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if assert.Len(t, response.Trends, 1) {
+		assert.Equal(t, "improvement", response.Trends[0].Direction)
+	}
+	english := []string{"Excellent", "Very Good", "Good", "Average", "Below Average"}
+	for _, season := range response.Seasons {
+		assert.Contains(t, english, season.Stats.Stats.PerformanceRank)
+	}
+}
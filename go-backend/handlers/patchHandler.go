@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// mergePatchContentType é o media type de JSON Merge Patch (RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+// patchableField descreve um campo do jogador que pode ser alterado via PATCH
+type patchableField struct {
+	structField string
+	required    bool // campos obrigatórios não podem ser removidos com null
+	target      func(player *models.Player) interface{}
+}
+
+// patchablePlayerFields mapeia o nome JSON (igual ao da coluna) para o campo do jogador
+var patchablePlayerFields = map[string]patchableField{
+	"name":     {"Name", true, func(p *models.Player) interface{} { return &p.Name }},
+	"age":      {"Age", true, func(p *models.Player) interface{} { return &p.Age }},
+	"position": {"Position", true, func(p *models.Player) interface{} { return &p.Position }},
+	"team":     {"Team", true, func(p *models.Player) interface{} { return &p.Team }},
+	"goals":    {"Goals", false, func(p *models.Player) interface{} { return &p.Goals }},
+	"tackles":  {"Tackles", false, func(p *models.Player) interface{} { return &p.Tackles }},
+	"passes":   {"Passes", false, func(p *models.Player) interface{} { return &p.Passes }},
+}
+
+// PatchPlayer aplica um JSON Merge Patch (RFC 7396) ao jogador: apenas os campos
+// enviados são validados e gravados, inclusive valores zero, e null em uma
+// estatística a volta para o padrão (0)
+func PatchPlayer(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var player models.Player
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(c.ContentType())
+		if err != nil || (mediaType != mergePatchContentType && mediaType != binding.MIMEJSON) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type deve ser " + mergePatchContentType})
+			return
+		}
+
+		// Verifica se o jogador existe
+		if err := db.First(&player, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+			}
+			return
+		}
+
+		if !checkIfMatch(c, player) {
+			return
+		}
+
+		var patch map[string]json.RawMessage
+		if err := json.NewDecoder(c.Request.Body).Decode(&patch); err != nil || patch == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "O corpo deve ser um objeto JSON"})
+			return
+		}
+
+		patched := player
+		changed, err := applyPlayerPatch(&patched, patch)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+			return
+		}
+
+		changesTotals := slices.ContainsFunc(changed, func(field string) bool {
+			return field == "goals" || field == "tackles" || field == "passes"
+		})
+		if !checkTotalsEditable(c, db, player, changesTotals) {
+			return
+		}
+
+		if len(changed) > 0 {
+			updates := make(map[string]interface{}, len(changed))
+			for _, field := range changed {
+				updates[field] = patchFieldValue(&patched, field)
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := bumpPlayerVersion(tx, player); err != nil {
+					return err
+				}
+				return tx.Model(&player).Updates(updates).Error
+			})
+			if errors.Is(err, errVersionConflict) {
+				c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Jogador foi alterado por outra requisição"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar jogador: " + err.Error()})
+				return
+			}
+
+			invalidateAnalysisCache(c.Request.Context(), player.ID)
+
+			// Busca o jogador atualizado
+			db.First(&player, id)
+		}
+
+		c.Header("ETag", playerETag(player))
+		c.JSON(http.StatusOK, gin.H{
+			"player":         player,
+			"changed_fields": changed,
+		})
+	}
+}
+
+// applyPlayerPatch aplica o patch ao jogador e retorna, em ordem alfabética, os
+// campos cujo valor mudou
+func applyPlayerPatch(player *models.Player, patch map[string]json.RawMessage) ([]string, error) {
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		if _, ok := patchablePlayerFields[key]; !ok {
+			return nil, fmt.Errorf("campo %q não pode ser alterado", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	original := *player
+	var provided []string
+	for _, key := range keys {
+		field := patchablePlayerFields[key]
+		raw := patch[key]
+
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if field.required {
+				return nil, fmt.Errorf("campo %q é obrigatório e não pode ser removido", key)
+			}
+			raw = json.RawMessage("0")
+		}
+
+		if err := json.Unmarshal(raw, field.target(player)); err != nil {
+			return nil, fmt.Errorf("valor inválido para %q", key)
+		}
+		provided = append(provided, field.structField)
+	}
+
+	// Valida apenas os campos enviados, com as mesmas regras do cadastro
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok && len(provided) > 0 {
+		if err := validate.StructPartial(player, provided...); err != nil {
+			return nil, err
+		}
+	}
+
+	changed := []string{}
+	for _, key := range keys {
+		if patchFieldValue(player, key) != patchFieldValue(&original, key) {
+			changed = append(changed, key)
+		}
+	}
+	return changed, nil
+}
+
+// patchFieldValue retorna o valor atual de um campo alterável do jogador
+func patchFieldValue(player *models.Player, key string) interface{} {
+	switch target := patchablePlayerFields[key].target(player).(type) {
+	case *string:
+		return *target
+	case *int:
+		return *target
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchPlayer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.PATCH("/players/:id", PatchPlayer(db))
+
+	player := models.Player{
+		Name:     "João Silva",
+		Age:      25,
+		Position: "Atacante",
+		Team:     "Flamengo",
+		Goals:    15,
+		Tackles:  5,
+		Passes:   120,
+	}
+	db.Create(&player)
+
+	body := `{"goals": 0, "tackles": null, "team": "Flamengo", "age": 26}`
+	req, _ := http.NewRequest("PATCH", "/players/1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Player        models.Player `json:"player"`
+		ChangedFields []string      `json:"changed_fields"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, []string{"age", "goals", "tackles"}, response.ChangedFields)
+	assert.Equal(t, 0, response.Player.Goals)
+	assert.Equal(t, 0, response.Player.Tackles)
+	assert.Equal(t, 120, response.Player.Passes)
+	assert.Equal(t, 26, response.Player.Age)
+
+	var stored models.Player
+	db.First(&stored, player.ID)
+	assert.Equal(t, 0, stored.Goals)
+	assert.Equal(t, "João Silva", stored.Name)
+}
+
+func TestPatchPlayerInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.PATCH("/players/:id", PatchPlayer(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"})
+
+	cases := []struct {
+		body        string
+		contentType string
+		status      int
+	}{
+		{`{"name": null}`, "application/merge-patch+json", http.StatusBadRequest},
+		{`{"name": ""}`, "application/merge-patch+json", http.StatusBadRequest},
+		{`{"age": 0}`, "application/merge-patch+json", http.StatusBadRequest},
+		{`{"goals": -1}`, "application/merge-patch+json", http.StatusBadRequest},
+		{`{"goals": "dez"}`, "application/json", http.StatusBadRequest},
+		{`{"id": 7}`, "application/json", http.StatusBadRequest},
+		{`[1, 2]`, "application/json", http.StatusBadRequest},
+		{`{"goals": 1}`, "text/plain", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest("PATCH", "/players/1", bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.body)
+	}
+
+	var stored models.Player
+	db.First(&stored, 1)
+	assert.Equal(t, "João Silva", stored.Name)
+	assert.Equal(t, 25, stored.Age)
+}
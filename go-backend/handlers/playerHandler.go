@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mvcbotelho/scout-ai/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func CreatePlayer(db *gorm.DB) gin.HandlerFunc {
@@ -19,34 +21,56 @@ func CreatePlayer(db *gorm.DB) gin.HandlerFunc {
 		}
 
 		// Validação básica
-		if player.Name == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Nome é obrigatório"})
+		if err := validatePlayer(player); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		if player.Age <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Idade deve ser maior que zero"})
-			return
-		}
+		// A versão é controlada pelo servidor
+		player.Version = 0
 
-		if err := db.Create(&player).Error; err != nil {
+		// Atuações são registradas pelos endpoints próprios
+		if err := db.Omit(clause.Associations).Create(&player).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar jogador: " + err.Error()})
 			return
 		}
 
+		invalidateAnalysisCache(c.Request.Context(), player.ID)
+		c.Header("ETag", playerETag(player))
 		c.JSON(http.StatusCreated, player)
 	}
 }
 
+// GetPlayers lista jogadores com filtros, ordenação e paginação; o total e os
+// links de navegação vão nos headers X-Total-Count e Link
 func GetPlayers(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var players []models.Player
+		filter, err := parsePlayerFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros inválidos: " + err.Error()})
+			return
+		}
+
+		// Jogadores deletados só aparecem com include_deleted=true (DeletedAt preenchido)
+		base := db.Model(&models.Player{})
+		if filter.IncludeDeleted {
+			base = base.Unscoped()
+		}
+		query := filter.apply(base)
 
-		if err := db.Find(&players).Error; err != nil {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao contar jogadores: " + err.Error()})
+			return
+		}
+
+		players := []models.Player{}
+		if err := filter.paginate(query).Find(&players).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogadores: " + err.Error()})
 			return
 		}
 
+		setPaginationHeaders(c, filter.Page, filter.Limit, total)
 		c.JSON(http.StatusOK, players)
 	}
 }
@@ -71,6 +95,13 @@ func GetPlayerByID(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
+		etag := playerETag(player)
+		c.Header("ETag", etag)
+		if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag, true) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+
 		c.JSON(http.StatusOK, player)
 	}
 }
@@ -96,6 +127,10 @@ func UpdatePlayer(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
+		if !checkIfMatch(c, player) {
+			return
+		}
+
 		var input models.Player
 		if err := c.ShouldBindJSON(&input); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
@@ -103,24 +138,41 @@ func UpdatePlayer(db *gorm.DB) gin.HandlerFunc {
 		}
 
 		// Validação básica
-		if input.Name == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Nome é obrigatório"})
+		if err := validatePlayer(input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		if input.Age <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Idade deve ser maior que zero"})
+		// Campos zerados não são gravados, então só contam os totais enviados e diferentes dos atuais
+		changesTotals := (input.Goals != 0 && input.Goals != player.Goals) ||
+			(input.Tackles != 0 && input.Tackles != player.Tackles) ||
+			(input.Passes != 0 && input.Passes != player.Passes)
+		if !checkTotalsEditable(c, db, player, changesTotals) {
 			return
 		}
 
-		// Atualiza apenas os campos fornecidos
-		if err := db.Model(&player).Updates(input).Error; err != nil {
+		// Atualiza apenas os campos fornecidos, desde que ninguém tenha alterado o
+		// jogador desde a leitura
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := bumpPlayerVersion(tx, player); err != nil {
+				return err
+			}
+			return tx.Model(&player).Omit(clause.Associations, "Version").Updates(input).Error
+		})
+		if errors.Is(err, errVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Jogador foi alterado por outra requisição"})
+			return
+		}
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar jogador: " + err.Error()})
 			return
 		}
 
+		invalidateAnalysisCache(c.Request.Context(), player.ID)
+
 		// Busca o jogador atualizado
 		db.First(&player, id)
+		c.Header("ETag", playerETag(player))
 		c.JSON(http.StatusOK, player)
 	}
 }
@@ -135,6 +187,12 @@ func DeletePlayer(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
+		// purge=true remove o jogador definitivamente, inclusive se já estiver deletado
+		if c.Query("purge") == "true" {
+			purgePlayer(c, db, id)
+			return
+		}
+
 		// Verifica se o jogador existe antes de deletar
 		var player models.Player
 		if err := db.First(&player, id).Error; err != nil {
@@ -146,11 +204,131 @@ func DeletePlayer(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
-		if err := db.Delete(&player).Error; err != nil {
+		if !checkIfMatch(c, player) {
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := bumpPlayerVersion(tx, player); err != nil {
+				return err
+			}
+			return tx.Delete(&player).Error
+		})
+		if errors.Is(err, errVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Jogador foi alterado por outra requisição"})
+			return
+		}
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao deletar jogador: " + err.Error()})
 			return
 		}
 
+		invalidateAnalysisCache(c.Request.Context(), player.ID)
 		c.JSON(http.StatusOK, gin.H{"message": "Jogador deletado com sucesso"})
 	}
 }
+
+// RestorePlayer restaura um jogador deletado (soft delete)
+func RestorePlayer(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		var player models.Player
+		if err := db.Unscoped().First(&player, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+			}
+			return
+		}
+
+		if !player.DeletedAt.Valid {
+			c.JSON(http.StatusConflict, gin.H{"error": "Jogador não está deletado"})
+			return
+		}
+
+		if !checkIfMatch(c, player) {
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := bumpPlayerVersion(tx, player); err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(&player).Update("deleted_at", nil).Error
+		})
+		if errors.Is(err, errVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Jogador foi alterado por outra requisição"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao restaurar jogador: " + err.Error()})
+			return
+		}
+
+		invalidateAnalysisCache(c.Request.Context(), player.ID)
+
+		// Busca o jogador restaurado
+		db.First(&player, id)
+		c.Header("ETag", playerETag(player))
+		c.JSON(http.StatusOK, player)
+	}
+}
+
+// purgePlayer remove o jogador e suas atuações definitivamente; restrito a administradores
+func purgePlayer(c *gin.Context, db *gorm.DB, id string) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var player models.Player
+	if err := db.Unscoped().First(&player, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Jogador não encontrado"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogador: " + err.Error()})
+		}
+		return
+	}
+
+	if !checkIfMatch(c, player) {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("player_id = ?", player.ID).Delete(&models.Appearance{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("player_id = ?", player.ID).Delete(&models.Analysis{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&player).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover jogador: " + err.Error()})
+		return
+	}
+
+	invalidateAnalysisCache(c.Request.Context(), player.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Jogador removido definitivamente"})
+}
+
+// validatePlayer aplica as validações básicas de cadastro, compartilhadas com a importação
+func validatePlayer(player models.Player) error {
+	if player.Name == "" {
+		return errors.New("Nome é obrigatório")
+	}
+
+	if player.Age <= 0 {
+		return errors.New("Idade deve ser maior que zero")
+	}
+
+	return nil
+}
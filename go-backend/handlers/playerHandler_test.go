@@ -3,9 +3,11 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mvcbotelho/scout-ai/models"
@@ -19,7 +21,7 @@ func setupTestDB() *gorm.DB {
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&models.Player{})
+	db.AutoMigrate(&models.Player{}, &models.Appearance{}, &models.Season{}, &models.Analysis{}, &models.AnalysisJob{}, &models.AnalysisJobItem{}, &models.ChatSession{}, &models.ChatMessage{})
 	return db
 }
 
@@ -105,3 +107,225 @@ func TestGetPlayers(t *testing.T) {
 	assert.Len(t, players, 1)
 	assert.Equal(t, player.Name, players[0].Name)
 }
+
+func TestGetPlayersFiltersSortsAndPaginates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players", GetPlayers(db))
+
+	players := []models.Player{
+		{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15},
+		{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 8},
+		{Name: "Lucas Lima", Age: 19, Position: "Atacante", Team: "Santos", Goals: 22},
+		{Name: "André Souza", Age: 31, Position: "Atacante", Team: "Grêmio", Goals: 4},
+	}
+	for _, player := range players {
+		db.Create(&player)
+	}
+
+	req, _ := http.NewRequest("GET", "/players?position=atacante&min_goals=5&sort=goals&order=desc&limit=1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Header().Get("Link"), `page=2`)
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+
+	var page []models.Player
+	json.Unmarshal(w.Body.Bytes(), &page)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "Lucas Lima", page[0].Name)
+
+	req, _ = http.NewRequest("GET", "/players?position=atacante&min_goals=5&sort=goals&order=desc&limit=1&page=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	json.Unmarshal(w.Body.Bytes(), &page)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "João Silva", page[0].Name)
+	assert.NotContains(t, w.Header().Get("Link"), `rel="next"`)
+}
+
+func TestGetPlayersInvalidFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players", GetPlayers(db))
+
+	for _, query := range []string{"sort=salary", "order=sideways", "min_age=30&max_age=20", "limit=-1", "min_goals=abc"} {
+		req, _ := http.NewRequest("GET", "/players?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestUpdatePlayerIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players/:id", GetPlayerByID(db))
+	router.PUT("/players/:id", UpdatePlayer(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"})
+
+	req, _ := http.NewRequest("GET", "/players/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, `"1-1"`, etag)
+
+	req, _ = http.NewRequest("GET", "/players/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	// If-None-Match usa comparação fraca
+	req, _ = http.NewRequest("GET", "/players/1", nil)
+	req.Header.Set("If-None-Match", `"0-9", W/`+etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	update := func(age int, ifMatch string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"name":"João Silva","age":%d,"position":"Atacante","team":"Flamengo"}`, age)
+		req, _ := http.NewRequest("PUT", "/players/1", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// O primeiro scout atualiza com o ETag lido; o segundo, com o mesmo ETag, é rejeitado
+	w = update(26, etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+
+	w = update(27, etag)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// If-Match usa comparação forte: um ETag fraco nunca confere
+	w = update(27, `W/"1-2"`)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	var stored models.Player
+	db.First(&stored, 1)
+	assert.Equal(t, 26, stored.Age)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestDeletePlayerRequiresIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	PlayerConcurrency.RequireIfMatch = true
+	defer func() { PlayerConcurrency.RequireIfMatch = false }()
+
+	router := gin.New()
+	router.DELETE("/players/:id", DeletePlayer(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"})
+
+	req, _ := http.NewRequest("DELETE", "/players/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/players/1", nil)
+	req.Header.Set("If-Match", `"1-1"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDeleteListAndRestorePlayer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players", GetPlayers(db))
+	router.DELETE("/players/:id", DeletePlayer(db))
+	router.POST("/players/:id/restore", RestorePlayer(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"})
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras"})
+
+	send := func(method, url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Restaurar um jogador ativo é um conflito
+	assert.Equal(t, http.StatusConflict, send("POST", "/players/1/restore").Code)
+	assert.Equal(t, http.StatusOK, send("DELETE", "/players/1").Code)
+
+	var players []models.Player
+	json.Unmarshal(send("GET", "/players").Body.Bytes(), &players)
+	assert.Len(t, players, 1)
+
+	w := send("GET", "/players?include_deleted=true")
+	json.Unmarshal(w.Body.Bytes(), &players)
+	assert.Len(t, players, 2)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	assert.True(t, players[0].DeletedAt.Valid)
+
+	w = send("POST", "/players/1/restore")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-3"`, w.Header().Get("ETag"))
+
+	json.Unmarshal(send("GET", "/players").Body.Bytes(), &players)
+	assert.Len(t, players, 2)
+
+	assert.Equal(t, http.StatusNotFound, send("POST", "/players/999/restore").Code)
+}
+
+func TestPurgePlayerRequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.DELETE("/players/:id", DeletePlayer(db))
+
+	player := models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"}
+	db.Create(&player)
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Now(), Opponent: "Vasco", Minutes: 90})
+	db.Delete(&player)
+
+	purge := func(token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("DELETE", "/players/1?purge=true", nil)
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Sem token configurado as operações administrativas ficam desabilitadas
+	assert.Equal(t, http.StatusForbidden, purge("segredo").Code)
+
+	AdminAccess.Token = "segredo"
+	defer func() { AdminAccess.Token = "" }()
+
+	assert.Equal(t, http.StatusUnauthorized, purge("").Code)
+	assert.Equal(t, http.StatusUnauthorized, purge("errado").Code)
+	assert.Equal(t, http.StatusOK, purge("segredo").Code)
+
+	var count int64
+	db.Unscoped().Model(&models.Player{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Unscoped().Model(&models.Appearance{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	assert.Equal(t, http.StatusNotFound, purge("segredo").Code)
+}
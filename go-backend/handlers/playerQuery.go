@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// PlayerFilter representa os filtros, a ordenação e a paginação da listagem de jogadores
+type PlayerFilter struct {
+	Position   string `form:"position"`
+	Team       string `form:"team"`
+	MinAge     int    `form:"min_age" binding:"omitempty,min=1,max=100"`
+	MaxAge     int    `form:"max_age" binding:"omitempty,min=1,max=100"`
+	MinGoals   int    `form:"min_goals" binding:"omitempty,min=0"`
+	MinTackles int    `form:"min_tackles" binding:"omitempty,min=0"`
+	MinPasses  int    `form:"min_passes" binding:"omitempty,min=0"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=id name age position team goals tackles passes created_at updated_at"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1"`
+
+	// IncludeDeleted inclui jogadores deletados (soft delete) na listagem
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// parsePlayerFilter lê os filtros da query string, aplicando os valores padrão
+func parsePlayerFilter(c *gin.Context) (PlayerFilter, error) {
+	var filter PlayerFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		return filter, err
+	}
+
+	if filter.MinAge > 0 && filter.MaxAge > 0 && filter.MinAge > filter.MaxAge {
+		return filter, fmt.Errorf("min_age não pode ser maior que max_age")
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = "id"
+	}
+	if filter.Order == "" {
+		filter.Order = "asc"
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	} else if filter.Limit > maxPageLimit {
+		filter.Limit = maxPageLimit
+	}
+
+	return filter, nil
+}
+
+// apply adiciona à consulta as condições dos filtros (sem ordenação ou paginação)
+func (f PlayerFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.Position != "" {
+		query = query.Where("LOWER(position) = LOWER(?)", f.Position)
+	}
+	if f.Team != "" {
+		query = query.Where("LOWER(team) = LOWER(?)", f.Team)
+	}
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	if f.MaxAge > 0 {
+		query = query.Where("age <= ?", f.MaxAge)
+	}
+	if f.MinGoals > 0 {
+		query = query.Where("goals >= ?", f.MinGoals)
+	}
+	if f.MinTackles > 0 {
+		query = query.Where("tackles >= ?", f.MinTackles)
+	}
+	if f.MinPasses > 0 {
+		query = query.Where("passes >= ?", f.MinPasses)
+	}
+	return query
+}
+
+// sorted adiciona a ordenação; o ID desempata para que as páginas sejam estáveis
+func (f PlayerFilter) sorted(query *gorm.DB) *gorm.DB {
+	// Sort e Order já foram validados pelo binding, então podem ir direto para o SQL
+	query = query.Order(f.Sort + " " + f.Order)
+	if f.Sort != "id" {
+		query = query.Order("id " + f.Order)
+	}
+	return query
+}
+
+// paginate adiciona ordenação, offset e limite da página solicitada
+func (f PlayerFilter) paginate(query *gorm.DB) *gorm.DB {
+	return f.sorted(query).Offset((f.Page - 1) * f.Limit).Limit(f.Limit)
+}
+
+// setPaginationHeaders informa o total de registros e os links de navegação
+// (RFC 8288), mantendo o corpo da resposta como uma lista simples
+func setPaginationHeaders(c *gin.Context, page, limit int, total int64) {
+	lastPage := int((total + int64(limit) - 1) / int64(limit))
+	if lastPage == 0 {
+		lastPage = 1
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.Header("X-Page", strconv.Itoa(page))
+	c.Header("X-Per-Page", strconv.Itoa(limit))
+
+	links := []string{
+		fmt.Sprintf(`<%s>; rel="first"`, pageURL(c.Request.URL, 1, limit)),
+		fmt.Sprintf(`<%s>; rel="last"`, pageURL(c.Request.URL, lastPage, limit)),
+	}
+	if page < lastPage {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(c.Request.URL, page+1, limit)))
+	}
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(c.Request.URL, page-1, limit)))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// pageURL monta a URL da página informada preservando os demais parâmetros
+func pageURL(requestURL *url.URL, page, limit int) string {
+	query := requestURL.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return target.String()
+}
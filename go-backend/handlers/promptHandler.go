@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+	"gorm.io/gorm"
+)
+
+// ListPrompts lista os templates de prompt em uso, com versão e origem
+func ListPrompts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireAdmin(c) {
+			return
+		}
+		c.JSON(http.StatusOK, AIPrompts.List())
+	}
+}
+
+// ReloadPrompts recarrega os templates sem esperar a verificação periódica;
+// se algum não compilar, os anteriores continuam em uso
+func ReloadPrompts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireAdmin(c) {
+			return
+		}
+		if err := AIPrompts.Reload(); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Erro ao recarregar prompts: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, AIPrompts.List())
+	}
+}
+
+// PreviewPrompt renderiza o prompt com os dados reais, sem chamar o modelo:
+// player_analysis usa ?player_id (e ?season), comparative_analysis usa ?ids
+// (padrão: todos os jogadores) e chat_assistant usa os jogadores em foco na
+// conversa ?session_id; ?lang escolhe o idioma como nas análises
+func PreviewPrompt(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requireAdmin(c) {
+			return
+		}
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+
+		var prompt prompts.Prompt
+		var err error
+		switch c.Param("name") {
+		case prompts.PlayerAnalysis:
+			playerID := c.Query("player_id")
+			if playerID == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Informe o jogador em player_id"})
+				return
+			}
+			stats, ok := loadPlayerStatsByID(c, db, playerID, lang)
+			if !ok {
+				return
+			}
+			prompt, err = createAnalysisPrompt(stats, lang)
+
+		case prompts.ComparativeAnalysis:
+			ids := c.QueryArray("ids")
+			query := db.Preload("Appearances").Order("id")
+			if len(ids) > 0 {
+				query = query.Where("id IN ?", ids)
+			}
+			var players []models.Player
+			if err := query.Find(&players).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogadores: " + err.Error()})
+				return
+			}
+			if len(ids) > 0 && len(players) != len(ids) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Alguns jogadores não foram encontrados"})
+				return
+			}
+			prompt, err = createComparativePrompt(players, lang)
+
+		case prompts.ChatAssistant:
+			sessionID := c.Query("session_id")
+			if sessionID == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Informe a conversa em session_id"})
+				return
+			}
+			var session models.ChatSession
+			if err := db.First(&session, sessionID).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					c.JSON(http.StatusNotFound, gin.H{"error": "Conversa não encontrada"})
+				} else {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar conversa: " + err.Error()})
+				}
+				return
+			}
+			prompt, _, err = createChatPrompt(db, session.PlayerIDs, lang)
+
+		default:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Prompt não encontrado"})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao renderizar prompt: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, prompt)
+	}
+}
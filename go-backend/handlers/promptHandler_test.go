@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/cache"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/mvcbotelho/scout-ai/prompts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptAdminEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+	db.Create(&models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 8, Tackles: 30, Passes: 300})
+
+	router := gin.New()
+	router.GET("/admin/prompts", ListPrompts())
+	router.POST("/admin/prompts/reload", ReloadPrompts())
+	router.GET("/admin/prompts/:name/preview", PreviewPrompt(db))
+
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Sem token configurado as operações ficam desabilitadas
+	assert.Equal(t, http.StatusForbidden, request("GET", "/admin/prompts", "segredo").Code)
+
+	AdminAccess.Token = "segredo"
+	defer func() { AdminAccess.Token = "" }()
+	assert.Equal(t, http.StatusUnauthorized, request("GET", "/admin/prompts", "errado").Code)
+
+	w := request("GET", "/admin/prompts", "segredo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list []prompts.Template
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Len(t, list, 9)
+
+	w = request("GET", "/admin/prompts/player_analysis/preview?player_id=1", "segredo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var prompt prompts.Prompt
+	json.Unmarshal(w.Body.Bytes(), &prompt)
+	assert.Equal(t, prompts.PlayerAnalysis, prompt.Name)
+	assert.NotEmpty(t, prompt.Version)
+	assert.Contains(t, prompt.Text, "João Silva")
+
+	w = request("GET", "/admin/prompts/comparative_analysis/preview?ids=2", "segredo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &prompt)
+	assert.Contains(t, prompt.Text, "Pedro Santos")
+	assert.NotContains(t, prompt.Text, "João Silva")
+
+	db.Create(&models.ChatSession{Lang: "pt-BR", PlayerIDs: []uint{2}})
+	w = request("GET", "/admin/prompts/chat_assistant/preview?session_id=1", "segredo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &prompt)
+	assert.Equal(t, prompts.ChatAssistant, prompt.Name)
+	assert.Contains(t, prompt.Text, "«Pedro Santos»")
+	assert.Equal(t, http.StatusNotFound, request("GET", "/admin/prompts/chat_assistant/preview?session_id=99", "segredo").Code)
+
+	assert.Equal(t, http.StatusBadRequest, request("GET", "/admin/prompts/player_analysis/preview", "segredo").Code)
+	assert.Equal(t, http.StatusNotFound, request("GET", "/admin/prompts/player_analysis/preview?player_id=99", "segredo").Code)
+	assert.Equal(t, http.StatusNotFound, request("GET", "/admin/prompts/comparative_analysis/preview?ids=1&ids=99", "segredo").Code)
+	assert.Equal(t, http.StatusNotFound, request("GET", "/admin/prompts/outro/preview", "segredo").Code)
+
+	assert.Equal(t, http.StatusOK, request("POST", "/admin/prompts/reload", "segredo").Code)
+}
+
+func TestPromptOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, prompts.PlayerAnalysis+".tmpl")
+	os.WriteFile(path, []byte("{{- /* version: v9-teste */ -}}\nAvalie {{.Player.Name}} em JSON."), 0o644)
+
+	registry, err := prompts.New(dir)
+	assert.NoError(t, err)
+	previous := AIPrompts
+	AIPrompts = registry
+	defer func() { AIPrompts = previous }()
+
+	AICache.Store = cache.NewLRU(10)
+	defer func() { AICache.Store = nil }()
+
+	fake := &llm.Fake{Response: reportJSON("João Silva, atacante decisivo")}
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+
+	analyze := func() AnalysisResult {
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var result AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return result
+	}
+
+	result := analyze()
+	assert.Equal(t, "v9-teste", result.PromptVersion)
+	if assert.Len(t, fake.Requests(), 1) {
+		assert.Equal(t, "Avalie «João Silva» em JSON.", fake.Requests()[0].Messages[0].Content)
+	}
+
+	// Editar o template invalida o cache, mesmo sem mudar a versão
+	os.WriteFile(path, []byte("{{- /* version: v9-teste */ -}}\nAnalise {{.Player.Name}} em JSON."), 0o644)
+	assert.NoError(t, AIPrompts.Reload())
+
+	result = analyze()
+	assert.False(t, result.Cached)
+	assert.Len(t, fake.Requests(), 2)
+
+	// Um template inválido não substitui o que está em uso
+	os.WriteFile(path, []byte("Avalie {{.Player.Name"), 0o644)
+	assert.Error(t, AIPrompts.Reload())
+	result = analyze()
+	assert.True(t, result.Cached)
+	assert.Equal(t, "v9-teste", result.PromptVersion)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+)
+
+// Os textos do cadastro (nome, time, posição, temporada) vão para os prompts
+// cercados por estes delimitadores; os templates instruem o modelo a tratar o
+// conteúdo entre eles apenas como dado
+const (
+	fieldOpen  = "«"
+	fieldClose = "»"
+)
+
+// maxPromptFieldLength limita, em caracteres, cada texto do cadastro no prompt
+const maxPromptFieldLength = 80
+
+// fieldStripper remove dos textos do cadastro os delimitadores e os
+// caracteres usados para montar blocos, templates e marcações
+var fieldStripper = strings.NewReplacer(
+	fieldOpen, "", fieldClose, "",
+	"{", "", "}", "", "<", "", ">", "", "`", "", "[", "", "]", "",
+)
+
+// fieldInjectionPatterns encontram, no texto sem acentos e em minúsculas,
+// trechos dos textos do cadastro que tentam desviar o modelo, em português,
+// inglês e espanhol. São amplos de propósito: um nome ou time não tem motivo
+// para trazer "ignore", "system:" ou "nota máxima"
+var fieldInjectionPatterns = []*regexp.Regexp{
+	// "ignore as instruções anteriores", "disregard the rules above", "olvida las reglas"
+	regexp.MustCompile(`\b(ignor\w*|disregard\w*|forget|esquec\w*|olvid\w*|desconsider\w*)\b.{0,40}\b(instruc\w*|instructions?|prompts?|regras|reglas|rules)\b`),
+	// "novas instruções", "new instructions", "system prompt", "prompt do sistema"
+	regexp.MustCompile(`\b(nova|novas|new|nueva|nuevas)\s+(instrucoes|instructions?|instrucciones|regras|rules|reglas)\b`),
+	systemPromptPattern,
+	// Marcadores de papel e de formato de chat ou de template
+	regexp.MustCompile(`(^|\s)(system|assistant|user|sistema|assistente|usuario)\s*:`),
+	chatTokenPattern,
+	// Troca de papel: "you are now", "você agora é", "act as", "aja como", "actúa como"
+	regexp.MustCompile(`\b(you\s+are\s+now|voce\s+agora\s+e|ahora\s+eres|act\s+as|aja\s+como|atue\s+como|actua\s+como|pretend\s+to\s+be|finja\s+ser|finge\s+ser)\b`),
+	// Ordens sobre a resposta: "responda apenas", "respond only", "dê nota 10"
+	regexp.MustCompile(`\b(responda|respond|responde|answer|reply|escreva|write|escribe|diga|say|di)\b.{0,30}\b(apenas|somente|only|solo|solamente)\b`),
+	regexp.MustCompile(`\b(suggested_rating|recommended_level|risk_flags)\b|\b(nota|rating|score|calificacion)\s*(maxima|maximum|[:=]?\s*10)\b`),
+	regexp.MustCompile(`###`),
+}
+
+var (
+	// chatTokenPattern encontra tokens de formato de chat e de template
+	chatTokenPattern = regexp.MustCompile(`<\|?/?(im_start|im_end|system|endoftext)\|?>|\[/?inst\]|\{\{|\}\}`)
+	// systemPromptPattern encontra menções ao prompt do sistema
+	systemPromptPattern = regexp.MustCompile(`\b(system\s+prompt|prompt\s+do\s+sistema|prompt\s+del\s+sistema)\b`)
+)
+
+// outputInjectionPatterns valem para a resposta do modelo. Uma análise fala
+// de quem "ignora as regras táticas" ou do "sistema: 4-3-3", então só contam
+// marcas que não aparecem em texto de scouting
+var outputInjectionPatterns = []*regexp.Regexp{chatTokenPattern, systemPromptPattern}
+
+// looksLikeInstruction informa se o texto do cadastro parece uma instrução ao modelo
+func looksLikeInstruction(text string) bool {
+	return matchesAny(strings.ToLower(stripAccents(text)), fieldInjectionPatterns)
+}
+
+// looksHijacked informa se a resposta do modelo mostra que o prompt foi
+// desviado: traz tokens de chat, fala do prompt do sistema ou repete um texto
+// do cadastro dos jogadores que foi omitido do prompt por parecer instrução
+func looksHijacked(text string, players ...models.Player) bool {
+	if matchesAny(strings.ToLower(stripAccents(text)), outputInjectionPatterns) {
+		return true
+	}
+	normalized := " " + normalizeSearchText(text) + " "
+	for _, player := range players {
+		for _, field := range []string{player.Name, player.Position, player.Team} {
+			if !looksLikeInstruction(field) {
+				continue
+			}
+			if echoed := normalizeSearchText(field); echoed != "" && strings.Contains(normalized, " "+echoed+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesAny informa se algum dos padrões encontra o texto
+func matchesAny(text string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizePromptField remove caracteres de controle e invisíveis, os
+// delimitadores e as marcações, junta os espaços e limita o tamanho do texto
+func sanitizePromptField(value string) string {
+	return sanitizePromptText(value, maxPromptFieldLength)
+}
+
+// sanitizePromptText limpa o texto como sanitizePromptField, com até limit caracteres
+func sanitizePromptText(value string, limit int) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, value)
+	cleaned = strings.Join(strings.Fields(fieldStripper.Replace(cleaned)), " ")
+
+	if runes := []rune(cleaned); len(runes) > limit {
+		cleaned = strings.TrimSpace(string(runes[:limit])) + "…"
+	}
+	return cleaned
+}
+
+// promptField prepara um texto do cadastro para o prompt, limpo e cercado
+// pelos delimitadores. Texto que parece instrução ao modelo é omitido; ok é
+// false nesse caso
+func promptField(lang, value string) (field string, ok bool) {
+	if looksLikeInstruction(value) {
+		return fieldOpen + i18n.T(lang, "prompt.field_omitted") + fieldClose, false
+	}
+	return fieldOpen + sanitizePromptField(value) + fieldClose, true
+}
+
+// promptText prepara um texto longo já gerado pela API (como uma análise
+// anterior) para o prompt, com até limit caracteres. Só é omitido quando seria
+// recusado como resposta do modelo
+func promptText(lang, value string, limit int) string {
+	if looksHijacked(value) {
+		return fieldOpen + i18n.T(lang, "prompt.field_omitted") + fieldClose
+	}
+	return fieldOpen + sanitizePromptText(value, limit) + fieldClose
+}
+
+// promptPlayer retorna o jogador com nome, time e posição prontos para o
+// prompt no idioma lang e os campos omitidos por parecerem instruções
+func promptPlayer(lang string, player models.Player) (models.Player, []string) {
+	var omitted []string
+	fields := []struct {
+		name  string
+		value *string
+		text  string
+	}{
+		{"name", &player.Name, player.Name},
+		{"position", &player.Position, positionText(lang, player.Position)},
+		{"team", &player.Team, player.Team},
+	}
+	for _, field := range fields {
+		var ok bool
+		if *field.value, ok = promptField(lang, field.text); !ok {
+			omitted = append(omitted, field.name)
+		}
+	}
+	return player, omitted
+}
+
+// promptWarnings avisa, no idioma lang, quais campos do jogador foram omitidos
+// do prompt por parecerem instruções; o aviso também vai para o log
+func promptWarnings(lang string, player models.Player) []string {
+	_, omitted := promptPlayer(lang, player)
+	if len(omitted) == 0 {
+		return nil
+	}
+
+	log.Printf("Aviso: jogador %d com texto parecido com instruções ao modelo em %s (omitido do prompt)",
+		player.ID, strings.Join(omitted, ", "))
+	warnings := make([]string, 0, len(omitted))
+	for _, field := range omitted {
+		warnings = append(warnings, i18n.T(lang, "prompt.warning_omitted", i18n.T(lang, "field."+field)))
+	}
+	return warnings
+}
+
+// validateReportSubject confere se a análise gerada continua sendo sobre o
+// jogador pedido: o resumo cita o jogador pelo nome e nenhum texto da análise
+// indica que o prompt foi desviado (veja looksHijacked)
+func validateReportSubject(report models.ScoutReport, player models.Player) error {
+	texts := append([]string{report.Summary}, report.Strengths...)
+	texts = append(texts, report.Weaknesses...)
+	texts = append(texts, report.RiskFlags...)
+	for _, text := range texts {
+		if looksHijacked(text, player) {
+			return fmt.Errorf("%w: a análise contém instruções em vez de avaliar o jogador", llm.ErrInvalidOutput)
+		}
+	}
+
+	// Nome omitido do prompt: o modelo não tem como citá-lo
+	if looksLikeInstruction(player.Name) {
+		return nil
+	}
+	tokens := nameTokens(player.Name)
+	if len(tokens) == 0 {
+		return nil
+	}
+	summary := " " + normalizeSearchText(report.Summary) + " "
+	for _, token := range tokens {
+		if strings.Contains(summary, " "+token+" ") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: o resumo não cita o jogador %s pelo nome", llm.ErrInvalidOutput, sanitizePromptField(player.Name))
+}
+
+// nameParticles são partes do nome que não identificam o jogador
+var nameParticles = map[string]bool{"da": true, "de": true, "do": true, "das": true, "dos": true, "del": true, "e": true, "y": true}
+
+// nameTokens retorna as partes do nome que identificam o jogador, sem acentos
+func nameTokens(name string) []string {
+	var tokens []string
+	for _, token := range strings.Fields(normalizeSearchText(sanitizePromptField(name))) {
+		if !nameParticles[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"gorm.io/gorm"
+)
+
+// Cores da identidade visual do relatório (RGB)
+var (
+	reportBrandColor = [3]int{16, 94, 62}
+	reportLightColor = [3]int{232, 243, 237}
+	reportTextColor  = [3]int{40, 40, 40}
+)
+
+// PlayerReport gera o relatório de scouting do jogador em PDF, pronto para impressão.
+// Aceita ?season=ANO e ?lang como a análise e, com ai=true, inclui a narrativa do LLM
+// (strict_ai=true responde erro em vez de gerar o relatório sem a narrativa)
+func PlayerReport(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+		stats, ok := loadPlayerStats(c, db, lang)
+		if !ok {
+			return
+		}
+
+		analysis := generatePlayerAnalysis(stats, lang)
+
+		// A narrativa da IA complementa a análise estática, que sempre aparece no relatório
+		var narrative *AnalysisResult
+		if options := parseAIOptions(c, lang); options.Enabled {
+			result, err := generatePlayerAnalysisWithAI(c.Request.Context(), provider, stats.Player, stats, options)
+			if err != nil {
+				respondAIError(c, err, lang)
+				return
+			}
+			narrative = &result
+			// A nota do cabeçalho acompanha a da análise da IA
+			if result.AIUsed {
+				analysis.Rating = result.Rating
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := renderPlayerReport(&buf, analysis, stats, narrative, lang); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.report", err)})
+			return
+		}
+
+		filename := fmt.Sprintf("scout-report-%d.pdf", stats.Player.ID)
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+		c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+	}
+}
+
+// renderPlayerReport desenha o relatório em A4, no idioma lang, e o escreve em w
+func renderPlayerReport(w io.Writer, analysis AnalysisResult, stats PlayerStats, narrative *AnalysisResult, lang string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetTitle(i18n.T(lang, "report.title")+" - "+analysis.PlayerName, true)
+	pdf.SetCreator("Scout AI", true)
+	pdf.AliasNbPages("")
+
+	// As fontes padrão do PDF usam cp1252, suficiente para português, inglês e espanhol
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	generatedAt := time.Now().Format(i18n.T(lang, "report.date_format"))
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFillColor(reportBrandColor[0], reportBrandColor[1], reportBrandColor[2])
+		pdf.Rect(0, 0, 210, 22, "F")
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.SetXY(15, 6)
+		pdf.CellFormat(120, 10, "SCOUT AI", "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(60, 10, tr(i18n.T(lang, "report.title")), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(reportTextColor[0], reportTextColor[1], reportTextColor[2])
+		pdf.SetY(30)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(90, 10, tr(i18n.T(lang, "report.generated_at", generatedAt)), "", 0, "L", false, 0, "")
+		pdf.CellFormat(90, 10, tr(i18n.T(lang, "report.page", pdf.PageNo())), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	// Identificação do jogador e nota
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(150, 10, tr(analysis.PlayerName), "", 0, "L", false, 0, "")
+	pdf.SetFillColor(reportBrandColor[0], reportBrandColor[1], reportBrandColor[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(30, 16, fmt.Sprintf("%d/10", analysis.Rating), "", 0, "C", true, 0, "")
+	pdf.SetTextColor(reportTextColor[0], reportTextColor[1], reportTextColor[2])
+	pdf.SetXY(15, pdf.GetY()+10)
+	pdf.SetFont("Helvetica", "", 11)
+
+	subtitle := fmt.Sprintf("%s - %s - %s", positionText(lang, analysis.Position), analysis.Team, i18n.T(lang, "report.age", stats.Player.Age))
+	if analysis.Season != "" {
+		subtitle += " - " + i18n.T(lang, "report.season", analysis.Season)
+	}
+	pdf.CellFormat(150, 6, tr(subtitle), "", 1, "L", false, 0, "")
+	pdf.Ln(8)
+
+	// Estatísticas
+	reportSection(pdf, tr, i18n.T(lang, "report.stats"))
+	games := fmt.Sprintf("%d", stats.Stats.Games)
+	if stats.Stats.GamesEstimated {
+		games = i18n.T(lang, "report.estimated", stats.Stats.Games)
+	}
+	rows := [][2]string{
+		{i18n.T(lang, "report.games"), games},
+		{i18n.T(lang, "report.minutes"), fmt.Sprintf("%d", stats.Stats.MinutesPlayed)},
+		{i18n.T(lang, "report.totals"), fmt.Sprintf("%d / %d / %d", stats.Player.Goals, stats.Player.Tackles, stats.Player.Passes)},
+		{i18n.T(lang, "report.goals_per_game"), fmt.Sprintf("%.2f", stats.Stats.GoalsPerGame)},
+		{i18n.T(lang, "report.tackles_per_game"), fmt.Sprintf("%.2f", stats.Stats.TacklesPerGame)},
+		{i18n.T(lang, "report.passes_per_game"), fmt.Sprintf("%.2f", stats.Stats.PassesPerGame)},
+	}
+	if prior := stats.PriorTotals; prior != nil {
+		// Totais sem jogos nem minutos, em linha própria para não misturar bases
+		rows = append(rows, [2]string{i18n.T(lang, "report.prior_totals"),
+			fmt.Sprintf("%d / %d / %d", prior.Goals, prior.Tackles, prior.Passes)})
+	}
+	if stats.Stats.MinutesPlayed > 0 {
+		rows = append(rows, [2]string{i18n.T(lang, "report.per90"), fmt.Sprintf("%.2f / %.2f / %.2f",
+			stats.Stats.GoalsPer90, stats.Stats.TacklesPer90, stats.Stats.PassesPer90)})
+	}
+	rows = append(rows,
+		[2]string{i18n.T(lang, "report.efficiency"), fmt.Sprintf("%.1f", stats.Stats.Efficiency)},
+		[2]string{i18n.T(lang, "report.rank"), labelText(lang, stats.Stats.PerformanceRank)},
+	)
+	if stats.Trend != nil {
+		rows = append(rows, [2]string{i18n.T(lang, "report.trend", stats.Trend.FromSeason), labelText(lang, stats.Trend.Direction)})
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetFillColor(reportLightColor[0], reportLightColor[1], reportLightColor[2])
+	for i, row := range rows {
+		fill := i%2 == 0
+		pdf.CellFormat(70, 7, tr(row[0]), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(110, 7, tr(row[1]), "", 1, "L", fill, 0, "")
+	}
+	pdf.Ln(6)
+
+	// Análise e insights
+	reportSection(pdf, tr, i18n.T(lang, "report.analysis"))
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.MultiCell(0, 5.5, tr(analysis.Analysis), "", "J", false)
+	pdf.Ln(4)
+
+	if len(analysis.Insights) > 0 {
+		reportSection(pdf, tr, i18n.T(lang, "report.insights"))
+		reportBullets(pdf, tr, analysis.Insights)
+		pdf.Ln(4)
+	}
+
+	reportSection(pdf, tr, i18n.T(lang, "report.position_analysis"))
+	var positionLines []string
+	for _, line := range strings.Split(getPositionAnalysis(stats.Player.Position, stats.Player, stats, lang), "\n") {
+		if line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-")); line != "" {
+			positionLines = append(positionLines, line)
+		}
+	}
+	reportBullets(pdf, tr, positionLines)
+
+	if narrative != nil {
+		pdf.Ln(4)
+		reportSection(pdf, tr, i18n.T(lang, "report.narrative"))
+		pdf.SetFont("Helvetica", "", 10)
+		if narrative.AIUsed {
+			pdf.MultiCell(0, 5.5, tr(narrative.Analysis), "", "J", false)
+			if report := narrative.Report; report != nil {
+				details := []string{
+					i18n.T(lang, "report.recommended_level", report.RecommendedLevel),
+					i18n.T(lang, "report.suggested_rating", report.SuggestedRating),
+				}
+				for _, flag := range report.RiskFlags {
+					details = append(details, i18n.T(lang, "report.risk", flag))
+				}
+				pdf.Ln(2)
+				reportBullets(pdf, tr, details)
+			}
+		} else {
+			pdf.SetTextColor(120, 120, 120)
+			reason := i18n.T(lang, "report.ai_unavailable")
+			if narrative.AIStatus != nil {
+				reason = narrative.AIStatus.Message
+			}
+			pdf.MultiCell(0, 5.5, tr(i18n.T(lang, "report.ai_failed", reason)), "", "L", false)
+			pdf.SetTextColor(reportTextColor[0], reportTextColor[1], reportTextColor[2])
+		}
+	}
+
+	return pdf.Output(w)
+}
+
+// reportSection escreve o título de uma seção do relatório
+func reportSection(pdf *gofpdf.Fpdf, tr func(string) string, title string) {
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.SetTextColor(reportBrandColor[0], reportBrandColor[1], reportBrandColor[2])
+	pdf.CellFormat(0, 8, tr(title), "B", 1, "L", false, 0, "")
+	pdf.SetTextColor(reportTextColor[0], reportTextColor[1], reportTextColor[2])
+	pdf.Ln(2)
+}
+
+// reportBullets escreve uma lista com marcadores
+func reportBullets(pdf *gofpdf.Fpdf, tr func(string) string, items []string) {
+	pdf.SetFont("Helvetica", "", 10)
+	for _, item := range items {
+		pdf.CellFormat(6, 5.5, tr("•"), "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, 5.5, tr(item), "", "L", false)
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/report.pdf", PlayerReport(db, &llm.Fake{}))
+
+	player := models.Player{
+		Name:     "João Silva",
+		Age:      25,
+		Position: "Atacante",
+		Team:     "Flamengo",
+		Goals:    15,
+		Tackles:  5,
+		Passes:   120,
+	}
+	db.Create(&player)
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/report.pdf", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "scout-report-1.pdf")
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+	assert.True(t, bytes.Contains(w.Body.Bytes(), []byte("%%EOF")))
+}
+
+func TestPlayerReportNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/report.pdf", PlayerReport(db, &llm.Fake{}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/999/report.pdf", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRenderPlayerReportLongText(t *testing.T) {
+	player := models.Player{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras", Goals: 8, Tackles: 45, Passes: 350}
+	stats := calculatePlayerStats(player)
+	analysis := generatePlayerAnalysis(stats, i18n.Default)
+
+	// Narrativa longa o bastante para ocupar mais de uma página
+	narrative := analysis
+	narrative.AIUsed = true
+	narrative.Analysis = strings.Repeat("Meio-campista com ótima leitura de jogo e passes verticais. ", 200)
+
+	var buf bytes.Buffer
+	err := renderPlayerReport(&buf, analysis, stats, &narrative, i18n.Default)
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Regexp(t, `/Count [2-9]`, buf.String())
+}
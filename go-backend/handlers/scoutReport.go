@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+)
+
+// scoutReportRepairs é o número de vezes que o modelo é chamado de novo para
+// corrigir uma resposta que não segue o schema
+const scoutReportRepairs = 1
+
+// scoutReportSchema é o JSON Schema de models.ScoutReport enviado ao modelo
+var scoutReportSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "summary": {"type": "string"},
+    "strengths": {"type": "array", "items": {"type": "string"}},
+    "weaknesses": {"type": "array", "items": {"type": "string"}},
+    "recommended_level": {"type": "string", "enum": ["elite", "serie_a", "serie_b", "serie_c", "serie_d"]},
+    "risk_flags": {"type": "array", "items": {"type": "string"}},
+    "suggested_rating": {"type": "integer", "minimum": 1, "maximum": 10}
+  },
+  "required": ["summary", "strengths", "weaknesses", "recommended_level", "risk_flags", "suggested_rating"]
+}`)
+
+// requestScoutReport pede ao modelo a análise estruturada do jogador e valida
+// a resposta; respostas fora do schema ou que não tratam do jogador são
+// devolvidas ao modelo com o erro para correção, no idioma lang do prompt.
+// Retorna também o JSON validado, usado no cache de análises
+func requestScoutReport(ctx context.Context, provider llm.Provider, prompt string, player models.Player, lang string) (models.ScoutReport, string, error) {
+	req := llm.Request{
+		Messages: []llm.Message{{Role: llm.RoleUser, Content: prompt}},
+		Format:   scoutReportSchema,
+	}
+	response, err := provider.Generate(ctx, req)
+	if err != nil {
+		return models.ScoutReport{}, "", err
+	}
+	return repairScoutReport(ctx, provider, req, response, player, lang)
+}
+
+// repairScoutReport valida a resposta do modelo e, se ela não segue o schema
+// ou não trata do jogador, pede a correção até scoutReportRepairs vezes
+func repairScoutReport(ctx context.Context, provider llm.Provider, req llm.Request, response string, player models.Player, lang string) (models.ScoutReport, string, error) {
+	report, err := checkScoutReport(response, player)
+	for attempt := 0; err != nil && attempt < scoutReportRepairs; attempt++ {
+		req.Messages = append(req.Messages,
+			llm.Message{Role: llm.RoleAssistant, Content: response},
+			llm.Message{Role: llm.RoleUser, Content: i18n.T(lang, "prompt.repair",
+				strings.TrimPrefix(err.Error(), llm.ErrInvalidOutput.Error()+": "))},
+		)
+
+		var callErr error
+		if response, callErr = provider.Generate(ctx, req); callErr != nil {
+			return models.ScoutReport{}, "", callErr
+		}
+		report, err = checkScoutReport(response, player)
+	}
+	if err != nil {
+		return models.ScoutReport{}, "", err
+	}
+
+	encoded, _ := json.Marshal(report)
+	return report, string(encoded), nil
+}
+
+// checkScoutReport lê a análise estruturada e confere se ela trata do jogador pedido
+func checkScoutReport(response string, player models.Player) (models.ScoutReport, error) {
+	report, err := parseScoutReport(response)
+	if err != nil {
+		return models.ScoutReport{}, err
+	}
+	if err := validateReportSubject(report, player); err != nil {
+		return models.ScoutReport{}, err
+	}
+	return report, nil
+}
+
+// parseScoutReport lê e valida a análise estruturada; aceita o JSON cercado de
+// texto ou de um bloco de código, como alguns modelos respondem
+func parseScoutReport(response string) (models.ScoutReport, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return models.ScoutReport{}, fmt.Errorf("%w: resposta sem objeto JSON", llm.ErrInvalidOutput)
+	}
+
+	var report models.ScoutReport
+	if err := json.Unmarshal([]byte(response[start:end+1]), &report); err != nil {
+		return models.ScoutReport{}, fmt.Errorf("%w: JSON inválido: %v", llm.ErrInvalidOutput, err)
+	}
+	if err := validateScoutReport(report); err != nil {
+		return models.ScoutReport{}, fmt.Errorf("%w: %v", llm.ErrInvalidOutput, err)
+	}
+	return report, nil
+}
+
+// validateScoutReport confere os campos que o schema exige
+func validateScoutReport(report models.ScoutReport) error {
+	var problems []string
+	if strings.TrimSpace(report.Summary) == "" {
+		problems = append(problems, `"summary" vazio`)
+	}
+	lists := []struct {
+		name  string
+		items []string
+	}{
+		{"strengths", report.Strengths},
+		{"weaknesses", report.Weaknesses},
+		{"risk_flags", report.RiskFlags},
+	}
+	for _, list := range lists {
+		if list.items == nil {
+			problems = append(problems, fmt.Sprintf("%q ausente", list.name))
+		}
+	}
+	if !slices.Contains(models.RecommendedLevels, report.RecommendedLevel) {
+		problems = append(problems, fmt.Sprintf(`"recommended_level" deve ser um de %s`, strings.Join(models.RecommendedLevels, ", ")))
+	}
+	if report.SuggestedRating < 1 || report.SuggestedRating > 10 {
+		problems = append(problems, `"suggested_rating" deve estar entre 1 e 10`)
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// reportInsights resume a análise estruturada nos insights: pontos fortes,
+// áreas de melhoria e riscos
+func reportInsights(report models.ScoutReport) []string {
+	insights := make([]string, 0, len(report.Strengths)+len(report.Weaknesses)+len(report.RiskFlags))
+	insights = append(insights, report.Strengths...)
+	insights = append(insights, report.Weaknesses...)
+	return append(insights, report.RiskFlags...)
+}
+
+// applyScoutReport preenche o resultado com a análise estruturada da IA; a
+// nota sugerida pelo modelo substitui a calculada, para que nota e texto
+// venham da mesma avaliação
+func applyScoutReport(result *AnalysisResult, report models.ScoutReport) {
+	result.Analysis = report.Summary
+	result.Rating = report.SuggestedRating
+	result.Insights = reportInsights(report)
+	result.Report = &report
+	result.AIUsed = true
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// reportJSON monta a resposta estruturada de um modelo com o resumo informado
+func reportJSON(summary string) string {
+	response, _ := json.Marshal(models.ScoutReport{
+		Summary:          summary,
+		Strengths:        []string{"Finalização precisa"},
+		Weaknesses:       []string{"Pouca participação defensiva"},
+		RecommendedLevel: models.LevelSerieA,
+		RiskFlags:        []string{},
+		SuggestedRating:  8,
+	})
+	return string(response)
+}
+
+func TestParseScoutReport(t *testing.T) {
+	valid := reportJSON("Atacante de área")
+
+	report, err := parseScoutReport(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, "Atacante de área", report.Summary)
+	assert.Equal(t, models.LevelSerieA, report.RecommendedLevel)
+
+	// Modelos às vezes cercam o JSON com texto ou um bloco de código
+	report, err = parseScoutReport("Segue a análise:\n```json\n" + valid + "\n```")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, report.SuggestedRating)
+
+	invalid := map[string]string{
+		"sem JSON":           "Atacante com excelente finalização",
+		"JSON quebrado":      `{"summary": "Atacante"`,
+		"campos ausentes":    `{"summary": "Atacante"}`,
+		"nível inválido":     `{"summary": "A", "strengths": [], "weaknesses": [], "risk_flags": [], "recommended_level": "premier", "suggested_rating": 7}`,
+		"nota fora da faixa": `{"summary": "A", "strengths": [], "weaknesses": [], "risk_flags": [], "recommended_level": "elite", "suggested_rating": 11}`,
+	}
+	for name, response := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseScoutReport(response)
+			assert.True(t, errors.Is(err, llm.ErrInvalidOutput))
+		})
+	}
+}
+
+func TestAnalyzePlayerStructuredOutput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	analyze := func(fake *llm.Fake, query string) (*httptest.ResponseRecorder, AnalysisResult) {
+		router := gin.New()
+		router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+		req, _ := http.NewRequest("GET", "/analyze/players/1?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var result AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return w, result
+	}
+
+	// O schema vai na requisição e os campos estruturados preenchem o resultado
+	fake := &llm.Fake{Response: reportJSON("João Silva, atacante de área")}
+	_, result := analyze(fake, "ai=true")
+	assert.True(t, result.AIUsed)
+	assert.Equal(t, "João Silva, atacante de área", result.Analysis)
+	assert.Equal(t, []string{"Finalização precisa", "Pouca participação defensiva"}, result.Insights)
+	if assert.NotNil(t, result.Report) {
+		assert.Equal(t, models.LevelSerieA, result.Report.RecommendedLevel)
+		assert.Equal(t, 8, result.Report.SuggestedRating)
+	}
+	assert.JSONEq(t, string(scoutReportSchema), string(fake.Requests()[0].Format))
+
+	// Resposta fora do schema: o modelo recebe o erro e corrige
+	fake = &llm.Fake{Responses: []string{`{"summary": "Atacante"}`, reportJSON("João Silva, atacante corrigido")}}
+	_, result = analyze(fake, "ai=true")
+	assert.True(t, result.AIUsed)
+	assert.Equal(t, "João Silva, atacante corrigido", result.Analysis)
+	if requests := fake.Requests(); assert.Len(t, requests, 2) {
+		repair := requests[1].Messages
+		assert.Len(t, repair, 3)
+		assert.Equal(t, llm.RoleAssistant, repair[1].Role)
+		assert.Contains(t, repair[2].Content, "strengths")
+	}
+
+	// Sem correção: análise estática com invalid_output, ou 502 com strict_ai
+	fake = &llm.Fake{Response: "Atacante com excelente finalização"}
+	_, result = analyze(fake, "ai=true")
+	assert.False(t, result.AIUsed)
+	assert.Nil(t, result.Report)
+	assert.Equal(t, AIStatusInvalidOutput, result.AIStatus.Status)
+	assert.Len(t, fake.Requests(), 1+scoutReportRepairs)
+
+	w, _ := analyze(fake, "strict_ai=true")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestAnalyzePlayerSuggestedRating(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	player := models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 1, Passes: 10}
+	db.Create(&player)
+	calculated := calculateRating(player, calculatePlayerStats(player))
+	assert.NotEqual(t, 8, calculated)
+
+	analyze := func(fake *llm.Fake) AnalysisResult {
+		router := gin.New()
+		router.GET("/analyze/players/:id", AnalyzePlayer(db, fake))
+		req, _ := http.NewRequest("GET", "/analyze/players/1?ai=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var result AnalysisResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return result
+	}
+
+	// Com a IA, vale a nota sugerida pelo modelo, também no histórico
+	result := analyze(&llm.Fake{Response: reportJSON("João Silva, atacante de área")})
+	assert.True(t, result.AIUsed)
+	assert.Equal(t, 8, result.Rating)
+	var stored models.Analysis
+	db.Last(&stored)
+	assert.Equal(t, 8, stored.Rating)
+
+	// No fallback, vale a nota calculada
+	result = analyze(&llm.Fake{Err: errors.New("conexão recusada")})
+	assert.False(t, result.AIUsed)
+	assert.Equal(t, calculated, result.Rating)
+}
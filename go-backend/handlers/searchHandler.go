@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+
+	// minSearchScore é a similaridade mínima para um jogador entrar no resultado
+	minSearchScore = 0.6
+)
+
+// PlayerSearchResult representa um jogador encontrado e a similaridade com a busca (0-1)
+type PlayerSearchResult struct {
+	Player models.Player `json:"player"`
+	Score  float64       `json:"score"`
+}
+
+// searchIndexStatements cria as extensões e índices usados pela busca no Postgres.
+// unaccent não é IMMUTABLE, por isso o wrapper: só assim ela pode ser usada em índices
+var searchIndexStatements = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+		AS $$ SELECT public.unaccent('public.unaccent', $1) $$
+		LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT`,
+	`CREATE INDEX IF NOT EXISTS idx_players_name_trgm ON players USING gin (immutable_unaccent(lower(name)) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_players_team_trgm ON players USING gin (immutable_unaccent(lower(team)) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_players_search_tsv ON players USING gin (to_tsvector('simple', immutable_unaccent(lower(name || ' ' || team))))`,
+}
+
+// SetupPlayerSearch prepara os índices de busca textual; em bancos que não são
+// Postgres não faz nada, pois a busca usa a implementação em Go
+func SetupPlayerSearch(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	for _, statement := range searchIndexStatements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchPlayers busca jogadores por nome e time, ignorando acentos e tolerando erros de digitação
+func SearchPlayers(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := strings.TrimSpace(c.Query("q"))
+		if len([]rune(normalizeSearchText(query))) < 2 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "O parâmetro q deve ter pelo menos 2 caracteres"})
+			return
+		}
+
+		limit := defaultSearchLimit
+		if limitParam := c.Query("limit"); limitParam != "" {
+			parsed, err := strconv.Atoi(limitParam)
+			if err != nil || parsed < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Limite inválido"})
+				return
+			}
+			if parsed < maxSearchLimit {
+				limit = parsed
+			} else {
+				limit = maxSearchLimit
+			}
+		}
+
+		var results []PlayerSearchResult
+		var err error
+		if db.Dialector.Name() == "postgres" {
+			results, err = searchPlayersPostgres(db, query, limit)
+		} else {
+			results, err = searchPlayersFallback(db, query, limit)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar jogadores: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	}
+}
+
+// searchPlayersPostgres usa pg_trgm (similaridade por palavra) e tsvector sobre
+// os textos sem acento, aproveitando os índices de SetupPlayerSearch
+func searchPlayersPostgres(db *gorm.DB, query string, limit int) ([]PlayerSearchResult, error) {
+	var rows []struct {
+		models.Player
+		Score float64
+	}
+
+	term := "immutable_unaccent(lower(?))"
+	err := db.Model(&models.Player{}).
+		Select("players.*, GREATEST(word_similarity("+term+", immutable_unaccent(lower(name))), word_similarity("+term+", immutable_unaccent(lower(team)))) AS score", query, query).
+		Where(term+" <% immutable_unaccent(lower(name)) OR "+term+" <% immutable_unaccent(lower(team)) OR "+
+			"to_tsvector('simple', immutable_unaccent(lower(name || ' ' || team))) @@ plainto_tsquery('simple', "+term+")",
+			query, query, query).
+		Order("score DESC").
+		Order("id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PlayerSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, PlayerSearchResult{Player: row.Player, Score: row.Score})
+	}
+	return results, nil
+}
+
+// searchPlayersFallback pontua os jogadores em Go, em lotes, para bancos sem pg_trgm (ex.: SQLite dos testes)
+func searchPlayersFallback(db *gorm.DB, query string, limit int) ([]PlayerSearchResult, error) {
+	normalizedQuery := normalizeSearchText(query)
+	results := []PlayerSearchResult{}
+
+	var batch []models.Player
+	err := db.FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+		for _, player := range batch {
+			score := searchScore(normalizedQuery, normalizeSearchText(player.Name))
+			if teamScore := searchScore(normalizedQuery, normalizeSearchText(player.Team)); teamScore > score {
+				score = teamScore
+			}
+			if score >= minSearchScore {
+				results = append(results, PlayerSearchResult{Player: player, Score: score})
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Player.ID < results[j].Player.ID
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// normalizeSearchText remove acentos, pontuação e diferenças de caixa ("João" -> "joao")
+func normalizeSearchText(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(stripAccents(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// stripAccents remove os acentos do texto ("Grêmio" -> "Gremio")
+func stripAccents(text string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		return text
+	}
+	return stripped
+}
+
+// searchScore mede quanto o texto atende à busca: cada palavra da busca é
+// comparada à palavra mais parecida do texto e a nota final é a média
+func searchScore(query, text string) float64 {
+	queryWords := strings.Fields(query)
+	textWords := strings.Fields(text)
+	if len(queryWords) == 0 || len(textWords) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, queryWord := range queryWords {
+		best := 0.0
+		for _, textWord := range textWords {
+			if score := wordScore(queryWord, textWord); score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+	return total / float64(len(queryWords))
+}
+
+// wordScore compara duas palavras: prefixos contam como quase iguais e o
+// restante é medido pela distância de edição
+func wordScore(queryWord, textWord string) float64 {
+	if queryWord == textWord {
+		return 1
+	}
+
+	query := []rune(queryWord)
+	text := []rune(textWord)
+	if len(query) >= 3 && strings.HasPrefix(textWord, queryWord) {
+		return 0.9
+	}
+
+	longest := len(query)
+	if len(text) > longest {
+		longest = len(text)
+	}
+	return 1 - float64(editDistance(query, text))/float64(longest)
+}
+
+// editDistance calcula a distância de Damerau-Levenshtein restrita: inserção,
+// remoção, substituição e troca de letras vizinhas ("jaoo" -> "joao") custam 1
+func editDistance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := 0; j <= len(b); j++ {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPlayers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players/search", SearchPlayers(db))
+	router.GET("/players/:id", GetPlayerByID(db))
+
+	players := []models.Player{
+		{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"},
+		{Name: "Pedro Santos", Age: 28, Position: "Meio-campo", Team: "Palmeiras"},
+		{Name: "Conceição Araújo", Age: 22, Position: "Zagueiro", Team: "São Paulo"},
+	}
+	for _, player := range players {
+		db.Create(&player)
+	}
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"Joao", "João Silva"},
+		{"joão slva", "João Silva"},
+		{"Jaoo", "João Silva"},
+		{"conceicao araujo", "Conceição Araújo"},
+		{"Sao Paulo", "Conceição Araújo"},
+		{"Palmeiars", "Pedro Santos"},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest("GET", "/players/search?q="+url.QueryEscape(tc.query), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, tc.query)
+
+		var results []PlayerSearchResult
+		json.Unmarshal(w.Body.Bytes(), &results)
+		if assert.NotEmpty(t, results, tc.query) {
+			assert.Equal(t, tc.expected, results[0].Player.Name, tc.query)
+		}
+	}
+}
+
+func TestSearchPlayersNoMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players/search", SearchPlayers(db))
+
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"})
+
+	req, _ := http.NewRequest("GET", "/players/search?q=Ronaldinho", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+}
+
+func TestSearchPlayersMissingQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/players/search", SearchPlayers(db))
+
+	req, _ := http.NewRequest("GET", "/players/search?q=%20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNormalizeSearchText(t *testing.T) {
+	assert.Equal(t, "joao silva", normalizeSearchText("  João   SILVA "))
+	assert.Equal(t, "conceicao araujo", normalizeSearchText("Conceição Araújo"))
+	assert.Equal(t, "sao paulo sp", normalizeSearchText("São Paulo-SP"))
+}
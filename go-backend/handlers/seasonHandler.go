@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// SeasonTrend representa a variação de desempenho entre duas temporadas
+type SeasonTrend struct {
+	FromSeason              string  `json:"from_season"`
+	ToSeason                string  `json:"to_season"`
+	GamesChange             int     `json:"games_change"`
+	GoalsPerGameChange      float64 `json:"goals_per_game_change"`
+	TacklesPerGameChange    float64 `json:"tackles_per_game_change"`
+	PassesPerGameChange     float64 `json:"passes_per_game_change"`
+	EfficiencyPerGameChange float64 `json:"efficiency_per_game_change"`
+	Direction               string  `json:"direction"`
+}
+
+// SeasonSummary representa o desempenho do jogador em uma temporada
+type SeasonSummary struct {
+	Year   int         `json:"year"`
+	Name   string      `json:"name"`
+	Stats  PlayerStats `json:"stats"`
+	Rating int         `json:"rating"`
+}
+
+// errNoSeasonAppearances indica que o jogador não atuou na temporada
+var errNoSeasonAppearances = errors.New("jogador sem atuações na temporada")
+
+// trendThreshold é a variação relativa mínima para considerar evolução ou queda
+const trendThreshold = 0.10
+
+// CreateSeason cadastra uma temporada
+func CreateSeason(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var season models.Season
+
+		if err := c.ShouldBindJSON(&season); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+			return
+		}
+
+		if !season.EndDate.After(season.StartDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Data final deve ser posterior à data inicial"})
+			return
+		}
+
+		if season.Name == "" {
+			season.Name = fmt.Sprintf("%d/%02d", season.Year, (season.Year+1)%100)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := checkSeasonConflicts(tx, season); err != nil {
+				return err
+			}
+			// Temporadas deletadas por versões anteriores (soft delete) ainda
+			// ocupam o ano no índice único
+			if err := tx.Unscoped().Where("year = ? AND deleted_at IS NOT NULL", season.Year).Delete(&models.Season{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&season).Error
+		})
+		var conflict *seasonConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": conflict.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar temporada: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, season)
+	}
+}
+
+// seasonConflictError indica uma temporada com o mesmo ano ou com datas em
+// comum com outra já cadastrada
+type seasonConflictError struct {
+	existing models.Season
+	sameYear bool
+}
+
+func (e *seasonConflictError) Error() string {
+	if e.sameYear {
+		return fmt.Sprintf("Já existe uma temporada para o ano %d (%s)", e.existing.Year, e.existing.Name)
+	}
+	return fmt.Sprintf("As datas se sobrepõem às da temporada %s (%s a %s)", e.existing.Name,
+		e.existing.StartDate.Format("2006-01-02"), e.existing.EndDate.Format("2006-01-02"))
+}
+
+// checkSeasonConflicts confere se a temporada pode ser cadastrada: o ano é
+// único e cada partida pertence a uma temporada só, então os intervalos de
+// datas (com a data final inclusiva) não podem se sobrepor
+func checkSeasonConflicts(tx *gorm.DB, season models.Season) error {
+	var existing models.Season
+	err := tx.Where("year = ?", season.Year).First(&existing).Error
+	if err == nil {
+		return &seasonConflictError{existing: existing, sameYear: true}
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	err = tx.Where("start_date < ? AND end_date > ?", season.EndDate.AddDate(0, 0, 1), season.StartDate.AddDate(0, 0, -1)).
+		Order("start_date").
+		First(&existing).Error
+	if err == nil {
+		return &seasonConflictError{existing: existing}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetSeasons lista as temporadas em ordem cronológica
+func GetSeasons(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var seasons []models.Season
+
+		if err := db.Order("year").Find(&seasons).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar temporadas: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, seasons)
+	}
+}
+
+// DeleteSeason remove uma temporada definitivamente, liberando o ano para um
+// novo cadastro; as atuações do período são mantidas
+func DeleteSeason(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			return
+		}
+
+		var season models.Season
+		if err := db.First(&season, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Temporada não encontrada"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar temporada: " + err.Error()})
+			}
+			return
+		}
+
+		if err := db.Unscoped().Delete(&season).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao deletar temporada: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Temporada deletada com sucesso"})
+	}
+}
+
+// CompareSeasons compara lado a lado o desempenho de um jogador em várias
+// temporadas; classificações e tendências seguem o idioma pedido (?lang)
+func CompareSeasons(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+		id := c.Param("id")
+
+		// Validação do ID
+		if _, err := strconv.Atoi(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": i18n.T(lang, "error.invalid_id")})
+			return
+		}
+
+		var player models.Player
+		if err := db.First(&player, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.player_not_found")})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_player", err)})
+			}
+			return
+		}
+
+		// Sem o parâmetro seasons, compara todas as temporadas cadastradas
+		query := db.Order("year")
+		if years := c.QueryArray("seasons"); len(years) > 0 {
+			query = query.Where("year IN ?", years)
+		}
+
+		var seasons []models.Season
+		if err := query.Find(&seasons).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_seasons", err)})
+			return
+		}
+
+		summaries := []SeasonSummary{}
+		var previous *PlayerStats
+		var trends []SeasonTrend
+		for _, season := range seasons {
+			stats, err := seasonStats(db, player, season)
+			if errors.Is(err, errNoSeasonAppearances) {
+				continue
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": i18n.T(lang, "error.fetch_appearances", err)})
+				return
+			}
+
+			if previous != nil {
+				trends = append(trends, *localizedTrend(lang, calculateSeasonTrend(*previous, stats)))
+			}
+
+			summaries = append(summaries, SeasonSummary{
+				Year:   season.Year,
+				Name:   season.Name,
+				Stats:  localizedStats(lang, stats),
+				Rating: calculateRating(stats.Player, stats),
+			})
+			previous = &stats
+		}
+
+		if len(summaries) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": i18n.T(lang, "error.no_appearances_in_seasons")})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"player_id":   player.ID,
+			"player_name": player.Name,
+			"seasons":     summaries,
+			"trends":      trends,
+		})
+	}
+}
+
+// seasonStats calcula as estatísticas do jogador restritas às atuações da temporada
+func seasonStats(db *gorm.DB, player models.Player, season models.Season) (PlayerStats, error) {
+	var appearances []models.Appearance
+
+	// A data final é inclusiva: partidas em qualquer horário do último dia contam
+	err := db.Where("player_id = ? AND match_date >= ? AND match_date < ?",
+		player.ID, season.StartDate, season.EndDate.AddDate(0, 0, 1)).
+		Order("match_date").
+		Find(&appearances).Error
+	if err != nil {
+		return PlayerStats{}, err
+	}
+
+	if len(appearances) == 0 {
+		return PlayerStats{}, errNoSeasonAppearances
+	}
+
+	// Os totais vêm das atuações da temporada; os anteriores à primeira
+	// atuação não pertencem a nenhuma temporada
+	scoped := player
+	scoped.Appearances = appearances
+	scoped.PriorGoals, scoped.PriorTackles, scoped.PriorPasses = 0, 0, 0
+
+	stats := calculatePlayerStats(scoped)
+	stats.Season = season.Name
+	return stats, nil
+}
+
+// seasonScopedStats calcula as estatísticas da temporada do ano informado e a
+// tendência em relação à temporada anterior, quando ela existe
+func seasonScopedStats(db *gorm.DB, player models.Player, year int) (PlayerStats, error) {
+	var season models.Season
+	if err := db.Where("year = ?", year).First(&season).Error; err != nil {
+		return PlayerStats{}, err
+	}
+
+	stats, err := seasonStats(db, player, season)
+	if err != nil {
+		return PlayerStats{}, err
+	}
+
+	var previousSeason models.Season
+	err = db.Where("year < ?", year).Order("year DESC").First(&previousSeason).Error
+	if err == gorm.ErrRecordNotFound {
+		return stats, nil
+	}
+	if err != nil {
+		return PlayerStats{}, err
+	}
+
+	previous, err := seasonStats(db, player, previousSeason)
+	if errors.Is(err, errNoSeasonAppearances) {
+		return stats, nil
+	}
+	if err != nil {
+		return PlayerStats{}, err
+	}
+
+	stats.Trend = calculateSeasonTrend(previous, stats)
+	return stats, nil
+}
+
+// calculateSeasonTrend calcula a variação das médias por jogo entre duas temporadas
+func calculateSeasonTrend(previous, current PlayerStats) *SeasonTrend {
+	previousEfficiency := previous.Stats.Efficiency / float64(previous.Stats.Games)
+	currentEfficiency := current.Stats.Efficiency / float64(current.Stats.Games)
+
+	trend := &SeasonTrend{
+		FromSeason:              previous.Season,
+		ToSeason:                current.Season,
+		GamesChange:             current.Stats.Games - previous.Stats.Games,
+		GoalsPerGameChange:      current.Stats.GoalsPerGame - previous.Stats.GoalsPerGame,
+		TacklesPerGameChange:    current.Stats.TacklesPerGame - previous.Stats.TacklesPerGame,
+		PassesPerGameChange:     current.Stats.PassesPerGame - previous.Stats.PassesPerGame,
+		EfficiencyPerGameChange: currentEfficiency - previousEfficiency,
+		Direction:               trendStable,
+	}
+
+	// A direção considera a eficiência por jogo, que não depende do número de partidas
+	if previousEfficiency > 0 {
+		relative := trend.EfficiencyPerGameChange / previousEfficiency
+		if relative > trendThreshold {
+			trend.Direction = trendImproving
+		} else if relative < -trendThreshold {
+			trend.Direction = trendDeclining
+		}
+	} else if currentEfficiency > 0 {
+		trend.Direction = trendImproving
+	}
+
+	return trend
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/i18n"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupSeasonData(db *gorm.DB) models.Player {
+	db.Create(&models.Season{Year: 2024, Name: "2024/25", StartDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)})
+	db.Create(&models.Season{Year: 2025, Name: "2025/26", StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)})
+
+	player := models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo"}
+	db.Create(&player)
+
+	// Uma partida discreta em 2024/25 e duas melhores em 2025/26
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Date(2024, 9, 1, 16, 0, 0, 0, time.UTC), Opponent: "Santos", Minutes: 90, Passes: 20})
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Date(2025, 9, 7, 16, 0, 0, 0, time.UTC), Opponent: "Santos", Minutes: 90, Goals: 2, Passes: 30})
+	db.Create(&models.Appearance{PlayerID: player.ID, MatchDate: time.Date(2026, 6, 30, 21, 0, 0, 0, time.UTC), Opponent: "Grêmio", Minutes: 90, Goals: 1, Passes: 25})
+	syncPlayerTotals(db, player.ID)
+
+	return player
+}
+
+func TestCreateSeasonConflicts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupSeasonData(db)
+
+	router := gin.New()
+	router.POST("/seasons", CreateSeason(db))
+	router.DELETE("/seasons/:id", DeleteSeason(db))
+
+	create := func(body string) int {
+		req, _ := http.NewRequest("POST", "/seasons", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Ano repetido e datas sobrepostas, inclusive no último dia de 2025/26
+	assert.Equal(t, http.StatusConflict, create(`{"year": 2025, "start_date": "2026-07-01T00:00:00Z", "end_date": "2027-06-30T00:00:00Z"}`))
+	assert.Equal(t, http.StatusConflict, create(`{"year": 2026, "start_date": "2026-01-01T00:00:00Z", "end_date": "2026-12-31T00:00:00Z"}`))
+	assert.Equal(t, http.StatusConflict, create(`{"year": 2026, "start_date": "2026-06-30T00:00:00Z", "end_date": "2027-06-29T00:00:00Z"}`))
+	assert.Equal(t, http.StatusCreated, create(`{"year": 2026, "start_date": "2026-07-01T00:00:00Z", "end_date": "2027-06-30T00:00:00Z"}`))
+
+	// A remoção libera o ano para um novo cadastro
+	req, _ := http.NewRequest("DELETE", "/seasons/3", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusCreated, create(`{"year": 2026, "start_date": "2026-08-01T00:00:00Z", "end_date": "2027-05-31T00:00:00Z"}`))
+
+	// Temporada deletada com soft delete por uma versão anterior também não bloqueia o ano
+	db.Where("year = ?", 2024).Delete(&models.Season{})
+	assert.Equal(t, http.StatusCreated, create(`{"year": 2024, "start_date": "2024-07-01T00:00:00Z", "end_date": "2025-06-30T00:00:00Z"}`))
+
+	var count int64
+	db.Unscoped().Model(&models.Season{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestAnalyzePlayerBySeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupSeasonData(db)
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1?season=2025", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response AnalysisResult
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "2025/26", response.Season)
+	if assert.NotNil(t, response.Trend) {
+		assert.Equal(t, "2024/25", response.Trend.FromSeason)
+		assert.Equal(t, "evolução", response.Trend.Direction)
+		assert.InDelta(t, 1.5, response.Trend.GoalsPerGameChange, 0.001)
+	}
+	assert.Contains(t, response.Analysis, "Em relação à temporada 2024/25")
+}
+
+func TestAnalyzePlayerUnknownSeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupSeasonData(db)
+
+	router := gin.New()
+	router.GET("/analyze/players/:id", AnalyzePlayer(db, &llm.Fake{}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1?season=2019", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCompareSeasons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	setupSeasonData(db)
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/seasons", CompareSeasons(db))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/seasons", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Seasons []SeasonSummary `json:"seasons"`
+		Trends  []SeasonTrend   `json:"trends"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Seasons, 2)
+	assert.Equal(t, 1, response.Seasons[0].Stats.Stats.Games)
+	assert.Equal(t, 2, response.Seasons[1].Stats.Stats.Games)
+	assert.Len(t, response.Trends, 1)
+}
+
+func TestCreateAnalysisPromptIncludesTrend(t *testing.T) {
+	db := setupTestDB()
+	player := setupSeasonData(db)
+
+	stats, err := seasonScopedStats(db, player, 2025)
+	assert.NoError(t, err)
+
+	prompt, err := createAnalysisPrompt(stats, i18n.Default)
+	assert.NoError(t, err)
+	assert.Contains(t, prompt.Text, "TENDÊNCIA ANO A ANO («2024/25» → «2025/26»)")
+}
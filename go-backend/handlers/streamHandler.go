@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"gorm.io/gorm"
+)
+
+// StreamPlayerAnalysis gera a análise com IA via Server-Sent Events: envia o
+// evento "start", um evento "token" para cada trecho do JSON recebido do modelo
+// e, ao final, "done" com a análise validada (report, insights e rating). Se o modelo falhar,
+// envia "error" com o ai_status e o "done" traz a análise estática, exceto com
+// strict_ai=true ou no tempo limite com o fallback desabilitado (AIBehavior),
+// quando o stream termina no "error". Um texto já no cache de análises é
+// enviado em um único evento "token"
+func StreamPlayerAnalysis(db *gorm.DB, provider llm.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := requestLang(c)
+		if !ok {
+			return
+		}
+		stats, ok := loadPlayerStats(c, db, lang)
+		if !ok {
+			return
+		}
+		player := stats.Player
+		ctx := c.Request.Context()
+		options := parseAIOptions(c, lang)
+		strict := options.Strict
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no") // evita buffer em proxies como o nginx
+
+		result := generatePlayerAnalysis(stats, lang)
+		result.PromptWarnings = promptWarnings(lang, player)
+		prompt, err := createAnalysisPrompt(stats, lang)
+		if provider == nil {
+			err = errNoProvider
+		}
+		if err != nil {
+			result.AIStatus = newAIStatus(err, lang)
+			c.SSEvent("error", gin.H{"error": result.AIStatus.Message, "ai_status": result.AIStatus})
+			if !strict {
+				c.SSEvent("done", result)
+			}
+			return
+		}
+
+		key := playerAnalysisCacheKey(provider, prompt, stats)
+		analysis, cached := lookupAIText(ctx, key, options)
+		setCacheHeaders(c, cacheCount(cached), cacheCount(!cached))
+
+		c.SSEvent("start", gin.H{
+			"player_id":   player.ID,
+			"player_name": player.Name,
+			"provider":    provider.Name(),
+		})
+		c.Writer.Flush()
+
+		var report models.ScoutReport
+		if cached {
+			c.SSEvent("token", gin.H{"text": analysis})
+			c.Writer.Flush()
+			report, err = parseScoutReport(analysis)
+		} else {
+			req := llm.Request{
+				Messages: []llm.Message{{Role: llm.RoleUser, Content: prompt.Text}},
+				Format:   scoutReportSchema,
+			}
+			analysis, err = llm.Stream(ctx, provider, req, func(token string) error {
+				c.SSEvent("token", gin.H{"text": token})
+				c.Writer.Flush()
+				return ctx.Err()
+			})
+			if err == nil {
+				report, analysis, err = repairScoutReport(ctx, provider, req, analysis, player, lang)
+			}
+		}
+
+		// Cliente desconectado: não há para quem enviar o restante
+		if ctx.Err() != nil {
+			return
+		}
+
+		result.AIStatus = newAIStatus(err, lang)
+		if err != nil {
+			c.SSEvent("error", gin.H{"error": result.AIStatus.Message, "ai_status": result.AIStatus})
+
+			// Falha sem fallback: o stream termina com o erro, sem "done"
+			if fatalAIError(ctx, err, strict) != nil {
+				return
+			}
+		} else {
+			applyScoutReport(&result, report)
+			result.PromptVersion = prompt.Version
+			result.Cached = cached
+			if !cached {
+				storeAIText(ctx, key, playerCacheGroup(player.ID), analysis)
+			}
+		}
+
+		recordAnalysis(db, stats, &result, provider)
+		c.SSEvent("done", result)
+		c.Writer.Flush()
+	}
+}
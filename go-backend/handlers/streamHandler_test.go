@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mvcbotelho/scout-ai/llm"
+	"github.com/mvcbotelho/scout-ai/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// sseEvent representa um evento lido da resposta SSE
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readSSEvents separa os eventos de uma resposta SSE
+func readSSEvents(body string) []sseEvent {
+	var events []sseEvent
+	var current sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			current.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			current.data += strings.TrimPrefix(line, "data:")
+		case line == "" && current.name != "":
+			events = append(events, current)
+			current = sseEvent{}
+		}
+	}
+	return events
+}
+
+func TestStreamPlayerAnalysis(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	fake := &llm.Fake{Response: reportJSON("João Silva é um atacante com excelente finalização. Joga pelos lados")}
+	router := gin.New()
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, fake))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/event-stream")
+
+	events := readSSEvents(w.Body.String())
+	if !assert.True(t, len(events) > 2) {
+		return
+	}
+	assert.Equal(t, "start", events[0].name)
+
+	var streamed string
+	for _, event := range events[1 : len(events)-1] {
+		assert.Equal(t, "token", event.name)
+		var token struct{ Text string }
+		json.Unmarshal([]byte(event.data), &token)
+		streamed += token.Text
+	}
+	assert.Equal(t, fake.Response, streamed)
+
+	last := events[len(events)-1]
+	assert.Equal(t, "done", last.name)
+	var result AnalysisResult
+	json.Unmarshal([]byte(last.data), &result)
+	assert.True(t, result.AIUsed)
+	assert.Equal(t, "João Silva é um atacante com excelente finalização. Joga pelos lados", result.Analysis)
+	assert.Equal(t, []string{"Finalização precisa", "Pouca participação defensiva"}, result.Insights)
+	assert.NotNil(t, result.Report)
+	assert.True(t, result.Rating >= 1 && result.Rating <= 10)
+}
+
+func TestStreamPlayerAnalysisFallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, &llm.Fake{Err: errors.New("indisponível")}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	events := readSSEvents(w.Body.String())
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "start", events[0].name)
+		assert.Equal(t, "error", events[1].name)
+		assert.Equal(t, "done", events[2].name)
+
+		var result AnalysisResult
+		json.Unmarshal([]byte(events[2].data), &result)
+		assert.False(t, result.AIUsed)
+		assert.NotEmpty(t, result.Analysis)
+		if assert.NotNil(t, result.AIStatus) {
+			assert.Equal(t, AIStatusUnavailable, result.AIStatus.Status)
+		}
+	}
+}
+
+func TestStreamPlayerAnalysisNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, &llm.Fake{}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/999/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStreamPlayerAnalysisTimeoutWithoutFallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	AIBehavior.FallbackOnTimeout = false
+	defer func() { AIBehavior.FallbackOnTimeout = true }()
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, &llm.Fake{Err: llm.ErrTimeout}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	events := readSSEvents(w.Body.String())
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "start", events[0].name)
+		assert.Equal(t, "error", events[1].name)
+		assert.Contains(t, events[1].data, `"status":"timeout"`)
+	}
+}
+
+func TestStreamPlayerAnalysisStrict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB()
+	db.Create(&models.Player{Name: "João Silva", Age: 25, Position: "Atacante", Team: "Flamengo", Goals: 15, Tackles: 5, Passes: 120})
+
+	router := gin.New()
+	router.GET("/analyze/players/:id/stream", StreamPlayerAnalysis(db, &llm.Fake{Err: fmt.Errorf("ollama: %w", llm.ErrInvalidOutput)}))
+
+	req, _ := http.NewRequest("GET", "/analyze/players/1/stream?strict_ai=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Com strict_ai o stream termina no erro, sem a análise estática
+	events := readSSEvents(w.Body.String())
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "error", events[1].name)
+		assert.Contains(t, events[1].data, `"status":"invalid_output"`)
+	}
+}
@@ -0,0 +1,94 @@
+// Package i18n escolhe o idioma das respostas e traduz as mensagens das
+// análises; o português do Brasil é o idioma padrão
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idiomas suportados
+const (
+	PT = "pt-BR"
+	EN = "en"
+	ES = "es"
+)
+
+// Default é o idioma usado quando nenhum suportado é pedido
+const Default = PT
+
+// Supported lista os idiomas suportados
+var Supported = []string{PT, EN, ES}
+
+// Parse normaliza a tag de idioma (pt, pt-PT, en-US, es-419...) para um dos
+// idiomas suportados, considerando apenas o idioma principal
+func Parse(tag string) (string, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	switch primary {
+	case "pt":
+		return PT, true
+	case "en":
+		return EN, true
+	case "es":
+		return ES, true
+	}
+	return "", false
+}
+
+// Negotiate escolhe o idioma suportado de maior preferência no header
+// Accept-Language (por exemplo "en-US,en;q=0.9,pt;q=0.8"); sem nenhum
+// suportado, retorna Default
+func Negotiate(header string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	// Empates mantêm a ordem do header
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
+
+// T retorna a mensagem no idioma, formatada com args como em fmt.Sprintf; sem
+// tradução no idioma usa a do idioma padrão e, sem nenhuma, a própria chave
+func T(lang, key string, args ...interface{}) string {
+	translations, ok := catalog[key]
+	if !ok {
+		return key
+	}
+	message, ok := translations[lang]
+	if !ok {
+		message = translations[Default]
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"pt":     PT,
+		"pt-BR":  PT,
+		"pt_PT":  PT,
+		"EN-us":  EN,
+		" es ":   ES,
+		"es-419": ES,
+	}
+	for tag, expected := range cases {
+		lang, ok := Parse(tag)
+		assert.True(t, ok, tag)
+		assert.Equal(t, expected, lang, tag)
+	}
+
+	for _, tag := range []string{"", "fr", "english", "*"} {
+		_, ok := Parse(tag)
+		assert.False(t, ok, tag)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, Default, Negotiate(""))
+	assert.Equal(t, Default, Negotiate("fr-FR, de;q=0.8"))
+	assert.Equal(t, EN, Negotiate("en-US,en;q=0.9,pt;q=0.8"))
+	assert.Equal(t, ES, Negotiate("fr, en;q=0.5, es-AR;q=0.9"))
+	// q=0 recusa o idioma
+	assert.Equal(t, Default, Negotiate("en;q=0"))
+	// Empates mantêm a ordem do header
+	assert.Equal(t, ES, Negotiate("es, en"))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Player not found", T(EN, "error.player_not_found"))
+	assert.Equal(t, "Jugador no encontrado", T(ES, "error.player_not_found"))
+	assert.Equal(t, "Idioma não suportado: fr (use pt-BR)", T(PT, "error.unsupported_lang", "fr", "pt-BR"))
+
+	// Idioma desconhecido usa o padrão; chave desconhecida retorna a própria chave
+	assert.Equal(t, "Jogador não encontrado", T("fr", "error.player_not_found"))
+	assert.Equal(t, "chave.inexistente", T(EN, "chave.inexistente"))
+}
+
+// verbPattern encontra os verbos de formatação, ignorando %%
+var verbPattern = regexp.MustCompile(`%%|%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z]`)
+
+func countVerbs(message string) int {
+	count := 0
+	for _, verb := range verbPattern.FindAllString(message, -1) {
+		if verb != "%%" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for key, translations := range catalog {
+		for _, lang := range Supported {
+			message, ok := translations[lang]
+			if !assert.True(t, ok, "%s sem tradução em %s", key, lang) {
+				continue
+			}
+			assert.Equal(t, countVerbs(translations[Default]), countVerbs(message), "%s: argumentos diferentes em %s", key, lang)
+		}
+	}
+}
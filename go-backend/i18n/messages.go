@@ -0,0 +1,735 @@
+package i18n
+
+// catalog guarda as mensagens de cada chave nos idiomas suportados
+var catalog = map[string]map[string]string{
+	// Erros dos endpoints de análise
+	"error.unsupported_lang": {
+		PT: "Idioma não suportado: %s (use %s)",
+		EN: "Unsupported language: %s (use %s)",
+		ES: "Idioma no soportado: %s (use %s)",
+	},
+	"error.invalid_id": {
+		PT: "ID inválido",
+		EN: "Invalid ID",
+		ES: "ID inválido",
+	},
+	"error.invalid_data": {
+		PT: "Dados inválidos: %v",
+		EN: "Invalid data: %v",
+		ES: "Datos inválidos: %v",
+	},
+	"error.player_not_found": {
+		PT: "Jogador não encontrado",
+		EN: "Player not found",
+		ES: "Jugador no encontrado",
+	},
+	"error.fetch_player": {
+		PT: "Erro ao buscar jogador: %v",
+		EN: "Error fetching player: %v",
+		ES: "Error al buscar el jugador: %v",
+	},
+	"error.fetch_players": {
+		PT: "Erro ao buscar jogadores: %v",
+		EN: "Error fetching players: %v",
+		ES: "Error al buscar jugadores: %v",
+	},
+	"error.players_not_found": {
+		PT: "Alguns jogadores não foram encontrados",
+		EN: "Some players were not found",
+		ES: "Algunos jugadores no fueron encontrados",
+	},
+	"error.compare_min_players": {
+		PT: "É necessário pelo menos 2 IDs de jogadores para comparação",
+		EN: "At least 2 player IDs are required for comparison",
+		ES: "Se necesitan al menos 2 IDs de jugadores para la comparación",
+	},
+	"error.invalid_season": {
+		PT: "Temporada inválida",
+		EN: "Invalid season",
+		ES: "Temporada inválida",
+	},
+	"error.season_not_found": {
+		PT: "Temporada não encontrada",
+		EN: "Season not found",
+		ES: "Temporada no encontrada",
+	},
+	"error.fetch_seasons": {
+		PT: "Erro ao buscar temporadas: %v",
+		EN: "Error fetching seasons: %v",
+		ES: "Error al buscar temporadas: %v",
+	},
+	"error.no_season_appearances": {
+		PT: "Jogador sem atuações na temporada",
+		EN: "Player has no appearances in the season",
+		ES: "Jugador sin actuaciones en la temporada",
+	},
+	"error.no_appearances_in_seasons": {
+		PT: "Jogador sem atuações nas temporadas solicitadas",
+		EN: "Player has no appearances in the requested seasons",
+		ES: "Jugador sin actuaciones en las temporadas solicitadas",
+	},
+	"error.fetch_appearances": {
+		PT: "Erro ao buscar atuações: %v",
+		EN: "Error fetching appearances: %v",
+		ES: "Error al buscar actuaciones: %v",
+	},
+	"error.report": {
+		PT: "Erro ao gerar relatório: %v",
+		EN: "Error generating report: %v",
+		ES: "Error al generar el informe: %v",
+	},
+	"error.no_players_to_analyze": {
+		PT: "Nenhum jogador para analisar",
+		EN: "No players to analyze",
+		ES: "Ningún jugador para analizar",
+	},
+	"error.job_not_found": {
+		PT: "Lote não encontrado",
+		EN: "Batch not found",
+		ES: "Lote no encontrado",
+	},
+	"error.fetch_job": {
+		PT: "Erro ao buscar lote: %v",
+		EN: "Error fetching batch: %v",
+		ES: "Error al buscar el lote: %v",
+	},
+	"error.create_job": {
+		PT: "Erro ao criar lote: %v",
+		EN: "Error creating batch: %v",
+		ES: "Error al crear el lote: %v",
+	},
+
+	// Exportações
+	"error.invalid_export_format": {
+		PT: "Formato de exportação inválido: use csv, xlsx ou ndjson",
+		EN: "Invalid export format: use csv, xlsx or ndjson",
+		ES: "Formato de exportación inválido: use csv, xlsx o ndjson",
+	},
+	"error.invalid_params": {
+		PT: "Parâmetros inválidos: %v",
+		EN: "Invalid parameters: %v",
+		ES: "Parámetros inválidos: %v",
+	},
+	"error.export_include_deleted": {
+		PT: "Parâmetros inválidos: include_deleted não é aceito nesta exportação",
+		EN: "Invalid parameters: include_deleted is not accepted by this export",
+		ES: "Parámetros inválidos: include_deleted no se acepta en esta exportación",
+	},
+
+	// Conversas com o assistente de scouting
+	"error.chat_not_found": {
+		PT: "Conversa não encontrada",
+		EN: "Chat session not found",
+		ES: "Conversación no encontrada",
+	},
+	"error.fetch_chat": {
+		PT: "Erro ao buscar conversa: %v",
+		EN: "Error fetching chat session: %v",
+		ES: "Error al buscar la conversación: %v",
+	},
+	"error.create_chat": {
+		PT: "Erro ao criar conversa: %v",
+		EN: "Error creating chat session: %v",
+		ES: "Error al crear la conversación: %v",
+	},
+	"error.save_chat": {
+		PT: "Erro ao salvar as mensagens da conversa: %v",
+		EN: "Error saving the chat messages: %v",
+		ES: "Error al guardar los mensajes de la conversación: %v",
+	},
+	"error.delete_chat": {
+		PT: "Erro ao deletar conversa: %v",
+		EN: "Error deleting chat session: %v",
+		ES: "Error al eliminar la conversación: %v",
+	},
+	"error.empty_message": {
+		PT: "A mensagem não pode ser vazia",
+		EN: "The message cannot be empty",
+		ES: "El mensaje no puede estar vacío",
+	},
+	"chat.deleted": {
+		PT: "Conversa deletada com sucesso",
+		EN: "Chat session deleted successfully",
+		ES: "Conversación eliminada con éxito",
+	},
+
+	// Situação da análise com IA (ai_status)
+	"ai_status.ok": {
+		PT: "Análise gerada pela IA",
+		EN: "Analysis generated by the AI",
+		ES: "Análisis generado por la IA",
+	},
+	"ai_status.timeout": {
+		PT: "Tempo limite da análise com IA excedido",
+		EN: "AI analysis timed out",
+		ES: "Se excedió el tiempo límite del análisis con IA",
+	},
+	"ai_status.unavailable": {
+		PT: "Serviço de IA indisponível",
+		EN: "AI service unavailable",
+		ES: "Servicio de IA no disponible",
+	},
+	"ai_status.model_missing": {
+		PT: "Modelo de IA não encontrado no servidor",
+		EN: "AI model not found on the server",
+		ES: "Modelo de IA no encontrado en el servidor",
+	},
+	"ai_status.invalid_output": {
+		PT: "A IA retornou uma resposta inválida",
+		EN: "The AI returned an invalid response",
+		ES: "La IA devolvió una respuesta inválida",
+	},
+
+	// Análise estática
+	"analysis.intro": {
+		PT: "%s, %d anos, atua como %s no %s. ",
+		EN: "%s, %d years old, plays as %s for %s. ",
+		ES: "%s, %d años, juega como %s en %s. ",
+	},
+	"analysis.season_totals": {
+		PT: "Na temporada, marcou %d gols, realizou %d tackles e %d passes. ",
+		EN: "This season, scored %d goals, made %d tackles and %d passes. ",
+		ES: "En la temporada, marcó %d goles, realizó %d entradas y %d pases. ",
+	},
+	"analysis.games_totals": {
+		PT: "Em %d jogos (%d minutos), marcou %d gols, realizou %d tackles e %d passes, média de %.2f gols por jogo. ",
+		EN: "In %d games (%d minutes), scored %d goals, made %d tackles and %d passes, averaging %.2f goals per game. ",
+		ES: "En %d partidos (%d minutos), marcó %d goles, realizó %d entradas y %d pases, con un promedio de %.2f goles por partido. ",
+	},
+	"analysis.prior_totals": {
+		PT: "Antes das partidas registradas, somava %d gols, %d tackles e %d passes. ",
+		EN: "Before the recorded games, had %d goals, %d tackles and %d passes. ",
+		ES: "Antes de los partidos registrados, sumaba %d goles, %d entradas y %d pases. ",
+	},
+	"analysis.efficiency": {
+		PT: "Sua eficiência geral é de %.1f pontos, classificando-o como %s. ",
+		EN: "Overall efficiency is %.1f points, rated as %s. ",
+		ES: "Su eficiencia general es de %.1f puntos, lo que lo clasifica como %s. ",
+	},
+	"analysis.highlights": {
+		PT: "Principais características: %s. ",
+		EN: "Key traits: %s. ",
+		ES: "Características principales: %s. ",
+	},
+	"analysis.trend": {
+		PT: "Em relação à temporada %s, apresenta %s (%+.2f gols, %+.2f tackles e %+.2f passes por jogo). ",
+		EN: "Compared to the %s season, shows %s (%+.2f goals, %+.2f tackles and %+.2f passes per game). ",
+		ES: "En relación con la temporada %s, presenta %s (%+.2f goles, %+.2f entradas y %+.2f pases por partido). ",
+	},
+	"analysis.recommend_high": {
+		PT: "Recomendado para times de alto nível.",
+		EN: "Recommended for top-level teams.",
+		ES: "Recomendado para equipos de alto nivel.",
+	},
+	"analysis.recommend_mid": {
+		PT: "Adequado para times de nível médio.",
+		EN: "Suitable for mid-level teams.",
+		ES: "Adecuado para equipos de nivel medio.",
+	},
+	"analysis.recommend_develop": {
+		PT: "Pode se beneficiar de mais tempo de desenvolvimento.",
+		EN: "Could benefit from more development time.",
+		ES: "Puede beneficiarse de más tiempo de desarrollo.",
+	},
+	"analysis.no_players": {
+		PT: "Nenhum jogador para análise",
+		EN: "No players to analyze",
+		ES: "Ningún jugador para analizar",
+	},
+	"analysis.no_players_comparative": {
+		PT: "Nenhum jogador para análise comparativa.",
+		EN: "No players for comparative analysis.",
+		ES: "Ningún jugador para el análisis comparativo.",
+	},
+
+	// Insights da análise estática
+	"insight.young": {
+		PT: "Jogador jovem com potencial de desenvolvimento",
+		EN: "Young player with development potential",
+		ES: "Jugador joven con potencial de desarrollo",
+	},
+	"insight.experienced": {
+		PT: "Jogador experiente, pode ser importante para liderança",
+		EN: "Experienced player, can be important for leadership",
+		ES: "Jugador experimentado, puede ser importante para el liderazgo",
+	},
+	"insight.forward_scorer": {
+		PT: "Artilheiro eficiente, excelente finalização",
+		EN: "Efficient goalscorer, excellent finishing",
+		ES: "Goleador eficiente, excelente definición",
+	},
+	"insight.forward_playmaker": {
+		PT: "Atacante que também participa da construção do jogo",
+		EN: "Forward who also contributes to build-up play",
+		ES: "Delantero que también participa en la construcción del juego",
+	},
+	"insight.midfielder_vision": {
+		PT: "Meio-campista com excelente visão de jogo",
+		EN: "Midfielder with excellent vision",
+		ES: "Centrocampista con excelente visión de juego",
+	},
+	"insight.midfielder_tackling": {
+		PT: "Meio-campista que também marca bem",
+		EN: "Midfielder who also defends well",
+		ES: "Centrocampista que también marca bien",
+	},
+	"insight.defender_tackling": {
+		PT: "Zagueiro com excelente marcação",
+		EN: "Defender with excellent marking",
+		ES: "Defensa con excelente marcaje",
+	},
+	"insight.defender_passing": {
+		PT: "Zagueiro com boa saída de bola",
+		EN: "Defender with good ball distribution",
+		ES: "Defensa con buena salida de balón",
+	},
+	"insight.goalkeeper_sweeper": {
+		PT: "Goleiro com boa saída do gol",
+		EN: "Goalkeeper who comes off the line well",
+		ES: "Portero con buenas salidas",
+	},
+	"insight.exceptional": {
+		PT: "Performance excepcional na temporada",
+		EN: "Exceptional performance this season",
+		ES: "Rendimiento excepcional en la temporada",
+	},
+	"insight.needs_improvement": {
+		PT: "Necessita de melhoria no desempenho",
+		EN: "Performance needs improvement",
+		ES: "Necesita mejorar su rendimiento",
+	},
+
+	// Classificação de performance
+	"rank.excellent": {
+		PT: "Excelente",
+		EN: "Excellent",
+		ES: "Excelente",
+	},
+	"rank.very_good": {
+		PT: "Muito Bom",
+		EN: "Very Good",
+		ES: "Muy Bueno",
+	},
+	"rank.good": {
+		PT: "Bom",
+		EN: "Good",
+		ES: "Bueno",
+	},
+	"rank.average": {
+		PT: "Regular",
+		EN: "Average",
+		ES: "Regular",
+	},
+	"rank.below_average": {
+		PT: "Abaixo da Média",
+		EN: "Below Average",
+		ES: "Por Debajo de la Media",
+	},
+
+	// Tendência entre temporadas
+	"trend.improving": {
+		PT: "evolução",
+		EN: "improvement",
+		ES: "evolución",
+	},
+	"trend.stable": {
+		PT: "estável",
+		EN: "stable",
+		ES: "estable",
+	},
+	"trend.declining": {
+		PT: "queda",
+		EN: "decline",
+		ES: "caída",
+	},
+
+	// Posições
+	"position.forward": {
+		PT: "Atacante",
+		EN: "Forward",
+		ES: "Delantero",
+	},
+	"position.midfielder": {
+		PT: "Meio-campista",
+		EN: "Midfielder",
+		ES: "Centrocampista",
+	},
+	"position.defender": {
+		PT: "Zagueiro",
+		EN: "Defender",
+		ES: "Defensa",
+	},
+	"position.goalkeeper": {
+		PT: "Goleiro",
+		EN: "Goalkeeper",
+		ES: "Portero",
+	},
+
+	// Trechos dos prompts e da análise por posição
+	"prompt.games_estimated": {
+		PT: "%d (estimado, sem atuações registradas)",
+		EN: "%d (estimated, no recorded appearances)",
+		ES: "%d (estimado, sin actuaciones registradas)",
+	},
+	"prompt.games": {
+		PT: "%d (%d minutos jogados)",
+		EN: "%d (%d minutes played)",
+		ES: "%d (%d minutos jugados)",
+	},
+	"prompt.per90_unavailable": {
+		PT: "indisponível",
+		EN: "unavailable",
+		ES: "no disponible",
+	},
+	"prompt.per90": {
+		PT: "%.2f gols, %.2f tackles, %.2f passes",
+		EN: "%.2f goals, %.2f tackles, %.2f passes",
+		ES: "%.2f goles, %.2f entradas, %.2f pases",
+	},
+	"prompt.trend_none": {
+		PT: "\nTENDÊNCIA ANO A ANO:\nTemporada %s sem temporada anterior para comparação.\n",
+		EN: "\nYEAR-OVER-YEAR TREND:\nSeason %s has no previous season for comparison.\n",
+		ES: "\nTENDENCIA AÑO A AÑO:\nTemporada %s sin temporada anterior para comparar.\n",
+	},
+	"prompt.trend": {
+		PT: `
+TENDÊNCIA ANO A ANO (%s → %s):
+- Variação de jogos: %+d
+- Gols por jogo: %+.2f
+- Tackles por jogo: %+.2f
+- Passes por jogo: %+.2f
+- Eficiência por jogo: %+.2f
+- Tendência geral: %s
+`,
+		EN: `
+YEAR-OVER-YEAR TREND (%s → %s):
+- Change in games: %+d
+- Goals per game: %+.2f
+- Tackles per game: %+.2f
+- Passes per game: %+.2f
+- Efficiency per game: %+.2f
+- Overall trend: %s
+`,
+		ES: `
+TENDENCIA AÑO A AÑO (%s → %s):
+- Variación de partidos: %+d
+- Goles por partido: %+.2f
+- Entradas por partido: %+.2f
+- Pases por partido: %+.2f
+- Eficiencia por partido: %+.2f
+- Tendencia general: %s
+`,
+	},
+	"prompt.repair": {
+		PT: "A resposta anterior não é válida (%s). Responda novamente apenas com o objeto JSON no formato pedido.",
+		EN: "The previous response is not valid (%s). Answer again with only the JSON object in the requested format.",
+		ES: "La respuesta anterior no es válida (%s). Responda de nuevo solo con el objeto JSON en el formato pedido.",
+	},
+	"prompt.field_omitted": {
+		PT: "texto omitido",
+		EN: "text omitted",
+		ES: "texto omitido",
+	},
+	"prompt.warning_omitted": {
+		PT: "O campo %s parece conter instruções ao modelo e foi omitido do prompt",
+		EN: "The %s field seems to contain instructions to the model and was left out of the prompt",
+		ES: "El campo %s parece contener instrucciones al modelo y se omitió del prompt",
+	},
+	"field.name": {
+		PT: "nome",
+		EN: "name",
+		ES: "nombre",
+	},
+	"field.position": {
+		PT: "posição",
+		EN: "position",
+		ES: "posición",
+	},
+	"field.team": {
+		PT: "time",
+		EN: "team",
+		ES: "equipo",
+	},
+	"position_analysis.forward": {
+		PT: `ANÁLISE DE ATAQUE:
+- Gols por jogo: %.2f
+- Participação no jogo: %d passes
+- Eficiência de finalização: %.1f%%
+- Comparação com padrões da posição: %s`,
+		EN: `ATTACKING ANALYSIS:
+- Goals per game: %.2f
+- Involvement in play: %d passes
+- Finishing efficiency: %.1f%%
+- Compared to position standards: %s`,
+		ES: `ANÁLISIS OFENSIVO:
+- Goles por partido: %.2f
+- Participación en el juego: %d pases
+- Eficiencia de definición: %.1f%%
+- Comparación con los estándares de la posición: %s`,
+	},
+	"position_analysis.midfielder": {
+		PT: `ANÁLISE DE MEIO-CAMPO:
+- Passes por jogo: %.2f
+- Marcação: %d tackles
+- Participação ofensiva: %d gols
+- Visão de jogo: %s`,
+		EN: `MIDFIELD ANALYSIS:
+- Passes per game: %.2f
+- Defending: %d tackles
+- Attacking contribution: %d goals
+- Vision: %s`,
+		ES: `ANÁLISIS DE MEDIOCAMPO:
+- Pases por partido: %.2f
+- Marcaje: %d entradas
+- Participación ofensiva: %d goles
+- Visión de juego: %s`,
+	},
+	"position_analysis.defender": {
+		PT: `ANÁLISE DEFENSIVA:
+- Tackles por jogo: %.2f
+- Saída de bola: %d passes
+- Participação ofensiva: %d gols
+- Eficiência defensiva: %s`,
+		EN: `DEFENSIVE ANALYSIS:
+- Tackles per game: %.2f
+- Ball distribution: %d passes
+- Attacking contribution: %d goals
+- Defensive efficiency: %s`,
+		ES: `ANÁLISIS DEFENSIVO:
+- Entradas por partido: %.2f
+- Salida de balón: %d pases
+- Participación ofensiva: %d goles
+- Eficiencia defensiva: %s`,
+	},
+	"position_analysis.goalkeeper": {
+		PT: `ANÁLISE DE GOLEIRO:
+- Saída do gol: %d tackles
+- Participação no jogo: %d passes
+- Comando de área: %s`,
+		EN: `GOALKEEPING ANALYSIS:
+- Coming off the line: %d tackles
+- Involvement in play: %d passes
+- Command of the area: %s`,
+		ES: `ANÁLISIS DE PORTERO:
+- Salidas: %d entradas
+- Participación en el juego: %d pases
+- Dominio del área: %s`,
+	},
+	"position_analysis.other": {
+		PT: "Posição não especificada - análise geral baseada em estatísticas.",
+		EN: "Position not specified - general analysis based on statistics.",
+		ES: "Posición no especificada - análisis general basado en estadísticas.",
+	},
+	"quality.above_average": {
+		PT: "Acima da média para a posição",
+		EN: "Above average for the position",
+		ES: "Por encima de la media de la posición",
+	},
+	"quality.average": {
+		PT: "Na média para a posição",
+		EN: "Average for the position",
+		ES: "En la media de la posición",
+	},
+	"quality.below_average": {
+		PT: "Abaixo da média para a posição",
+		EN: "Below average for the position",
+		ES: "Por debajo de la media de la posición",
+	},
+	"quality.passing_excellent": {
+		PT: "Excelente visão de jogo e distribuição",
+		EN: "Excellent vision and distribution",
+		ES: "Excelente visión de juego y distribución",
+	},
+	"quality.passing_good": {
+		PT: "Boa capacidade de distribuição",
+		EN: "Good distribution",
+		ES: "Buena capacidad de distribución",
+	},
+	"quality.passing_adequate": {
+		PT: "Capacidade de distribuição adequada",
+		EN: "Adequate distribution",
+		ES: "Capacidad de distribución adecuada",
+	},
+	"quality.passing_poor": {
+		PT: "Necessita melhorar distribuição",
+		EN: "Distribution needs improvement",
+		ES: "Necesita mejorar la distribución",
+	},
+	"quality.defending_excellent": {
+		PT: "Excelente marcação e recuperação",
+		EN: "Excellent marking and ball recovery",
+		ES: "Excelente marcaje y recuperación",
+	},
+	"quality.defending_good": {
+		PT: "Boa capacidade defensiva",
+		EN: "Good defensive ability",
+		ES: "Buena capacidad defensiva",
+	},
+	"quality.defending_adequate": {
+		PT: "Capacidade defensiva adequada",
+		EN: "Adequate defensive ability",
+		ES: "Capacidad defensiva adecuada",
+	},
+	"quality.defending_poor": {
+		PT: "Necessita melhorar marcação",
+		EN: "Marking needs improvement",
+		ES: "Necesita mejorar el marcaje",
+	},
+	"quality.goalkeeping_excellent": {
+		PT: "Excelente saída do gol e comando",
+		EN: "Excellent command and coming off the line",
+		ES: "Excelentes salidas y dominio del área",
+	},
+	"quality.goalkeeping_good": {
+		PT: "Boa saída do gol",
+		EN: "Comes off the line well",
+		ES: "Buenas salidas",
+	},
+	"quality.goalkeeping_poor": {
+		PT: "Necessita melhorar saída do gol",
+		EN: "Coming off the line needs improvement",
+		ES: "Necesita mejorar las salidas",
+	},
+
+	// Relatório em PDF
+	"report.title": {
+		PT: "Relatório de Scouting",
+		EN: "Scouting Report",
+		ES: "Informe de Scouting",
+	},
+	"report.generated_at": {
+		PT: "Gerado em %s",
+		EN: "Generated on %s",
+		ES: "Generado el %s",
+	},
+	"report.date_format": {
+		PT: "02/01/2006 15:04",
+		EN: "2006-01-02 15:04",
+		ES: "02/01/2006 15:04",
+	},
+	"report.page": {
+		PT: "Página %d/{nb}",
+		EN: "Page %d/{nb}",
+		ES: "Página %d/{nb}",
+	},
+	"report.age": {
+		PT: "%d anos",
+		EN: "%d years old",
+		ES: "%d años",
+	},
+	"report.season": {
+		PT: "Temporada %s",
+		EN: "Season %s",
+		ES: "Temporada %s",
+	},
+	"report.stats": {
+		PT: "Estatísticas",
+		EN: "Statistics",
+		ES: "Estadísticas",
+	},
+	"report.estimated": {
+		PT: "%d (estimado)",
+		EN: "%d (estimated)",
+		ES: "%d (estimado)",
+	},
+	"report.games": {
+		PT: "Jogos",
+		EN: "Games",
+		ES: "Partidos",
+	},
+	"report.minutes": {
+		PT: "Minutos jogados",
+		EN: "Minutes played",
+		ES: "Minutos jugados",
+	},
+	"report.totals": {
+		PT: "Gols / Tackles / Passes",
+		EN: "Goals / Tackles / Passes",
+		ES: "Goles / Entradas / Pases",
+	},
+	"report.prior_totals": {
+		PT: "Antes das partidas registradas",
+		EN: "Before recorded games",
+		ES: "Antes de los partidos registrados",
+	},
+	"report.goals_per_game": {
+		PT: "Gols por jogo",
+		EN: "Goals per game",
+		ES: "Goles por partido",
+	},
+	"report.tackles_per_game": {
+		PT: "Tackles por jogo",
+		EN: "Tackles per game",
+		ES: "Entradas por partido",
+	},
+	"report.passes_per_game": {
+		PT: "Passes por jogo",
+		EN: "Passes per game",
+		ES: "Pases por partido",
+	},
+	"report.per90": {
+		PT: "Por 90 minutos (G/T/P)",
+		EN: "Per 90 minutes (G/T/P)",
+		ES: "Por 90 minutos (G/E/P)",
+	},
+	"report.efficiency": {
+		PT: "Eficiência",
+		EN: "Efficiency",
+		ES: "Eficiencia",
+	},
+	"report.rank": {
+		PT: "Nível",
+		EN: "Level",
+		ES: "Nivel",
+	},
+	"report.trend": {
+		PT: "Tendência (%s)",
+		EN: "Trend (%s)",
+		ES: "Tendencia (%s)",
+	},
+	"report.analysis": {
+		PT: "Análise",
+		EN: "Analysis",
+		ES: "Análisis",
+	},
+	"report.insights": {
+		PT: "Insights",
+		EN: "Insights",
+		ES: "Observaciones",
+	},
+	"report.position_analysis": {
+		PT: "Análise por Posição",
+		EN: "Position Analysis",
+		ES: "Análisis por Posición",
+	},
+	"report.narrative": {
+		PT: "Narrativa da IA",
+		EN: "AI Narrative",
+		ES: "Narrativa de la IA",
+	},
+	"report.recommended_level": {
+		PT: "Nível recomendado: %s",
+		EN: "Recommended level: %s",
+		ES: "Nivel recomendado: %s",
+	},
+	"report.suggested_rating": {
+		PT: "Nota sugerida pela IA: %d/10",
+		EN: "AI suggested rating: %d/10",
+		ES: "Nota sugerida por la IA: %d/10",
+	},
+	"report.risk": {
+		PT: "Risco: %s",
+		EN: "Risk: %s",
+		ES: "Riesgo: %s",
+	},
+	"report.ai_unavailable": {
+		PT: "A IA não estava disponível",
+		EN: "The AI was not available",
+		ES: "La IA no estaba disponible",
+	},
+	"report.ai_failed": {
+		PT: "%s ao gerar este relatório; consulte a análise acima.",
+		EN: "%s while generating this report; see the analysis above.",
+		ES: "%s al generar este informe; consulte el análisis anterior.",
+	},
+}
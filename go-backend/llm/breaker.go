@@ -0,0 +1,210 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen indica que o circuit breaker está aberto e a chamada nem foi feita
+var ErrCircuitOpen = errors.New("circuit breaker do LLM aberto")
+
+// Estados do circuit breaker
+const (
+	BreakerClosed   = "closed"    // chamadas liberadas
+	BreakerOpen     = "open"      // chamadas bloqueadas até o fim da espera
+	BreakerHalfOpen = "half-open" // uma chamada de teste liberada
+)
+
+// BreakerConfig configura o circuit breaker
+type BreakerConfig struct {
+	FailureThreshold int           // falhas seguidas que abrem o circuito; zero desabilita
+	Cooldown         time.Duration // tempo aberto antes de liberar uma chamada de teste
+}
+
+// DefaultBreakerConfig configuração padrão
+var DefaultBreakerConfig = BreakerConfig{
+	FailureThreshold: 5,
+	Cooldown:         30 * time.Second,
+}
+
+// BreakerStatus é o estado do circuit breaker exposto no health check
+type BreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// CircuitBreaker interrompe as chamadas depois de falhas seguidas, para não
+// insistir em um backend fora do ar
+type CircuitBreaker struct {
+	config BreakerConfig
+	now    func() time.Time
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	lastError string
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker cria um circuit breaker fechado
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{config: config, now: time.Now, state: BreakerClosed}
+}
+
+// allow indica se uma chamada pode ser feita; no estado half-open só libera
+// uma chamada de teste por vez
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.config.Cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record registra o resultado de uma chamada liberada
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.lastError = ""
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.state == BreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release libera a chamada de teste sem registrar resultado (ex.: o chamador cancelou)
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if b.state == BreakerHalfOpen {
+		// Sem resultado do teste, volta a aguardar a próxima chamada de teste
+		b.state = BreakerOpen
+		b.openedAt = b.now().Add(-b.config.Cooldown)
+	}
+}
+
+// Status retorna o estado atual do circuit breaker
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.config.Cooldown)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// breakerProvider passa as chamadas pelo circuit breaker
+type breakerProvider struct {
+	provider Provider
+	breaker  *CircuitBreaker
+}
+
+// WithBreaker protege o provedor com o circuit breaker: enquanto ele estiver
+// aberto, as chamadas falham na hora com ErrCircuitOpen
+func WithBreaker(provider Provider, breaker *CircuitBreaker) Provider {
+	if breaker == nil || breaker.config.FailureThreshold <= 0 {
+		return provider
+	}
+	return &breakerProvider{provider: provider, breaker: breaker}
+}
+
+// unwrap retorna o provedor decorado
+func (p *breakerProvider) unwrap() Provider {
+	return p.provider
+}
+
+// Name identifica o provedor decorado
+func (p *breakerProvider) Name() string {
+	return p.provider.Name()
+}
+
+// Generate chama o provedor se o circuito permitir
+func (p *breakerProvider) Generate(ctx context.Context, req Request) (string, error) {
+	if !p.breaker.allow() {
+		return "", p.openError()
+	}
+
+	response, err := p.provider.Generate(ctx, req)
+	p.done(ctx, err)
+	return response, err
+}
+
+// GenerateStream chama o provedor em streaming se o circuito permitir
+func (p *breakerProvider) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	if !p.breaker.allow() {
+		return "", p.openError()
+	}
+
+	response, err := Stream(ctx, p.provider, req, onToken)
+	p.done(ctx, err)
+	return response, err
+}
+
+// done registra o resultado; cancelamentos pelo chamador não contam como falha do backend
+func (p *breakerProvider) done(ctx context.Context, err error) {
+	if err != nil && ctx.Err() != nil {
+		p.breaker.release()
+		return
+	}
+	p.breaker.record(err)
+}
+
+// openError descreve o circuito aberto com o horário da próxima tentativa
+func (p *breakerProvider) openError() error {
+	status := p.breaker.Status()
+	if status.RetryAt == nil {
+		return ErrCircuitOpen
+	}
+	return fmt.Errorf("%w até %s", ErrCircuitOpen, status.RetryAt.Format(time.RFC3339))
+}
+
+// Breaker retorna o circuit breaker que protege o provedor, se houver
+func Breaker(provider Provider) (*CircuitBreaker, bool) {
+	if protected, ok := provider.(*breakerProvider); ok {
+		return protected.breaker, true
+	}
+	return nil, false
+}
@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 2, Cooldown: 30 * time.Second})
+	breaker.now = func() time.Time { return now }
+
+	fake := &Fake{Err: errors.New("connection refused")}
+	provider := WithBreaker(fake, breaker)
+	ctx := context.Background()
+
+	provider.Generate(ctx, Prompt("Analise"))
+	assert.Equal(t, BreakerClosed, breaker.Status().State)
+	provider.Generate(ctx, Prompt("Analise"))
+	assert.Equal(t, BreakerOpen, breaker.Status().State)
+
+	// Aberto: falha na hora, sem chamar o backend
+	_, err := provider.Generate(ctx, Prompt("Analise"))
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Len(t, fake.Requests(), 2)
+
+	status := breaker.Status()
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", status.LastError)
+	assert.Equal(t, now.Add(30*time.Second), *status.RetryAt)
+
+	// Depois da espera, a chamada de teste que falha reabre o circuito
+	now = now.Add(31 * time.Second)
+	provider.Generate(ctx, Prompt("Analise"))
+	assert.Len(t, fake.Requests(), 3)
+	assert.Equal(t, BreakerOpen, breaker.Status().State)
+
+	// E a que funciona o fecha
+	now = now.Add(31 * time.Second)
+	fake.Err = nil
+	_, err = provider.Generate(ctx, Prompt("Analise"))
+	assert.NoError(t, err)
+	assert.Equal(t, BreakerClosed, breaker.Status().State)
+	assert.Equal(t, 0, breaker.Status().ConsecutiveFailures)
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: time.Second})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(errors.New("falha"))
+	now = now.Add(2 * time.Second)
+
+	assert.True(t, breaker.allow())
+	assert.Equal(t, BreakerHalfOpen, breaker.Status().State)
+	assert.False(t, breaker.allow())
+}
+
+func TestCircuitBreakerIgnoresCallerCancel(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+	provider := WithBreaker(&Fake{Response: "ok"}, breaker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := provider.Generate(ctx, Prompt("Analise"))
+
+	assert.Error(t, err)
+	assert.Equal(t, BreakerClosed, breaker.Status().State)
+	assert.Equal(t, 0, breaker.Status().ConsecutiveFailures)
+}
+
+func TestNewWithBreaker(t *testing.T) {
+	provider, err := New(Config{Provider: ProviderFake, Breaker: DefaultBreakerConfig})
+	assert.NoError(t, err)
+
+	breaker, ok := Breaker(provider)
+	assert.True(t, ok)
+	assert.Equal(t, BreakerClosed, breaker.Status().State)
+
+	provider, _ = New(Config{Provider: ProviderFake})
+	_, ok = Breaker(provider)
+	assert.False(t, ok)
+}
@@ -0,0 +1,157 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Fake é um provedor determinístico para testes e ambientes sem LLM: sem
+// Response configurada, responde com um texto fixo derivado da última mensagem
+// ou, se a requisição pede saída estruturada, com um JSON que segue o schema.
+// O texto cita Subject ou, sem ele, o primeiro dado entre « » das mensagens
+// (o nome do jogador nos prompts de análise), como um modelo real citaria
+type Fake struct {
+	Response string
+	Err      error
+
+	// Subject é o assunto citado no texto fixo
+	Subject string
+
+	// Responses são usadas uma por chamada, em ordem, antes de Response
+	Responses []string
+
+	mu       sync.Mutex
+	requests []Request
+}
+
+// Name identifica o provedor
+func (f *Fake) Name() string {
+	return ProviderFake
+}
+
+// Settings descreve o provedor de testes, que não tem parâmetros de amostragem
+func (f *Fake) Settings() Settings {
+	return Settings{Provider: ProviderFake, Model: ProviderFake}
+}
+
+// Generate registra a requisição e retorna a resposta configurada
+func (f *Fake) Generate(ctx context.Context, req Request) (string, error) {
+	return f.respond(ctx, req)
+}
+
+// GenerateStream retorna a mesma resposta de Generate, entregue palavra a palavra
+func (f *Fake) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	response, err := f.respond(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	for _, token := range strings.SplitAfter(response, " ") {
+		if err := onToken(token); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// respond registra a requisição e monta a resposta
+func (f *Fake) respond(ctx context.Context, req Request) (string, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	call := len(f.requests) - 1
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if f.Err != nil {
+		return "", f.Err
+	}
+	if call < len(f.Responses) {
+		return f.Responses[call], nil
+	}
+	if f.Response != "" {
+		return f.Response, nil
+	}
+
+	var last string
+	if len(req.Messages) > 0 {
+		last = req.Messages[len(req.Messages)-1].Content
+	}
+	text := fmt.Sprintf("Análise simulada (%d caracteres de prompt). Jogador com bom potencial.", len([]rune(last)))
+	if subject := f.subject(req); subject != "" {
+		text = fmt.Sprintf("Análise simulada de %s (%d caracteres de prompt). Jogador com bom potencial.", subject, len([]rune(last)))
+	}
+	if len(req.Format) > 0 {
+		var schema fakeSchema
+		if err := json.Unmarshal(req.Format, &schema); err != nil {
+			return "", fmt.Errorf("fake: schema inválido: %w", err)
+		}
+		response, _ := json.Marshal(schema.example(text))
+		return string(response), nil
+	}
+	return text, nil
+}
+
+// subject retorna Subject ou o primeiro dado entre « » das mensagens
+func (f *Fake) subject(req Request) string {
+	if f.Subject != "" {
+		return f.Subject
+	}
+	for _, message := range req.Messages {
+		if _, rest, found := strings.Cut(message.Content, "«"); found {
+			if subject, _, found := strings.Cut(rest, "»"); found {
+				return subject
+			}
+		}
+	}
+	return ""
+}
+
+// fakeSchema é o subconjunto de JSON Schema que o Fake sabe preencher
+type fakeSchema struct {
+	Type       string                `json:"type"`
+	Properties map[string]fakeSchema `json:"properties"`
+	Items      *fakeSchema           `json:"items"`
+	Enum       []interface{}         `json:"enum"`
+	Minimum    *float64              `json:"minimum"`
+}
+
+// example monta um valor válido para o schema, usando text nas strings
+func (s fakeSchema) example(text string) interface{} {
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	switch s.Type {
+	case "object":
+		object := map[string]interface{}{}
+		for name, property := range s.Properties {
+			object[name] = property.example(text)
+		}
+		return object
+	case "array":
+		if s.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{s.Items.example(text)}
+	case "integer", "number":
+		if s.Minimum != nil {
+			return *s.Minimum
+		}
+		return 0
+	case "boolean":
+		return false
+	default:
+		return text
+	}
+}
+
+// Requests retorna as requisições recebidas, na ordem
+func (f *Fake) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+)
+
+// limitProvider limita as chamadas simultâneas ao provedor
+type limitProvider struct {
+	provider Provider
+	slots    chan struct{}
+}
+
+// WithConcurrencyLimit permite no máximo limit chamadas simultâneas ao provedor
+// (somando todas as requisições e os lotes em segundo plano), protegendo um
+// servidor local como o Ollama; as demais esperam a vez até o contexto acabar.
+// Zero ou negativo desabilita o limite
+func WithConcurrencyLimit(provider Provider, limit int) Provider {
+	if limit <= 0 {
+		return provider
+	}
+	return &limitProvider{provider: provider, slots: make(chan struct{}, limit)}
+}
+
+// unwrap retorna o provedor decorado
+func (l *limitProvider) unwrap() Provider {
+	return l.provider
+}
+
+// Name identifica o provedor decorado
+func (l *limitProvider) Name() string {
+	return l.provider.Name()
+}
+
+// Generate espera uma vaga e chama o provedor
+func (l *limitProvider) Generate(ctx context.Context, req Request) (string, error) {
+	if err := l.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer l.release()
+
+	return l.provider.Generate(ctx, req)
+}
+
+// GenerateStream espera uma vaga e chama o provedor em streaming
+func (l *limitProvider) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	if err := l.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer l.release()
+
+	return Stream(ctx, l.provider, req, onToken)
+}
+
+// acquire ocupa uma vaga, desistindo quando o contexto termina
+func (l *limitProvider) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release libera a vaga ocupada
+func (l *limitProvider) release() {
+	<-l.slots
+}
@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingProvider registra o máximo de chamadas simultâneas
+type countingProvider struct {
+	Fake
+	delay       time.Duration
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (p *countingProvider) Generate(ctx context.Context, req Request) (string, error) {
+	current := p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+	for {
+		peak := p.maxInFlight.Load()
+		if current <= peak || p.maxInFlight.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	return p.Fake.Generate(ctx, req)
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	inner := &countingProvider{Fake: Fake{Response: "ok"}, delay: 20 * time.Millisecond}
+	provider := WithConcurrencyLimit(inner, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := provider.Generate(context.Background(), Prompt("Analise"))
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", response)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), inner.maxInFlight.Load())
+	assert.Len(t, inner.Requests(), 6)
+	assert.Equal(t, Settings{Provider: ProviderFake, Model: ProviderFake}, Describe(provider))
+}
+
+func TestWithConcurrencyLimitWaitCanceled(t *testing.T) {
+	inner := &countingProvider{Fake: Fake{Response: "ok"}, delay: time.Second}
+	provider := WithConcurrencyLimit(inner, 1)
+
+	// Ocupa a única vaga
+	release, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		provider.Generate(release, Prompt("Analise"))
+	}()
+	<-started
+	assert.Eventually(t, func() bool { return inner.inFlight.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Quem espera desiste quando o próprio contexto acaba, sem chamar o modelo
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := provider.Generate(ctx, Prompt("Analise"))
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), inner.inFlight.Load())
+}
+
+func TestWithConcurrencyLimitDisabled(t *testing.T) {
+	fake := &Fake{}
+	assert.Same(t, Provider(fake), WithConcurrencyLimit(fake, 0))
+}
@@ -0,0 +1,194 @@
+// Package llm define a interface dos modelos de linguagem usados nas análises
+// e as implementações disponíveis (Ollama, endpoints compatíveis com OpenAI e fake)
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Papéis das mensagens de uma conversa
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Nomes dos provedores aceitos em Config.Provider
+const (
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+	ProviderFake   = "fake"
+)
+
+// Erros que classificam as falhas do modelo
+var (
+	// ErrModelMissing indica que o modelo configurado não existe no servidor
+	ErrModelMissing = errors.New("modelo não encontrado no servidor")
+	// ErrInvalidOutput indica que o modelo respondeu algo que não pôde ser usado
+	ErrInvalidOutput = errors.New("resposta inválida do modelo")
+)
+
+// Message representa uma mensagem da conversa com o modelo
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Request representa uma requisição de geração de texto
+type Request struct {
+	Messages []Message
+
+	// Format pede uma resposta em JSON que siga este JSON Schema, usando a saída
+	// estruturada do backend; vazio para texto livre. O backend pode não cumprir
+	// o schema à risca, então quem chama ainda deve validar a resposta
+	Format json.RawMessage
+}
+
+// Prompt cria uma requisição com uma única mensagem do usuário
+func Prompt(prompt string) Request {
+	return Request{Messages: []Message{{Role: RoleUser, Content: prompt}}}
+}
+
+// Provider gera texto a partir de uma conversa; implementado por cada backend de LLM
+type Provider interface {
+	// Name identifica o provedor e o modelo (ex.: "ollama/llama3.2")
+	Name() string
+	// Generate retorna a resposta do modelo para as mensagens da requisição
+	Generate(ctx context.Context, req Request) (string, error)
+}
+
+// StreamProvider é implementado pelos provedores que entregam a resposta em partes
+type StreamProvider interface {
+	Provider
+	// GenerateStream chama onToken com cada trecho da resposta e retorna o texto
+	// completo; um erro de onToken interrompe a geração
+	GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error)
+}
+
+// Stream gera a resposta em partes quando o provedor suporta streaming; caso
+// contrário, entrega a resposta inteira em uma única chamada de onToken
+func Stream(ctx context.Context, provider Provider, req Request, onToken func(string) error) (string, error) {
+	if streamer, ok := provider.(StreamProvider); ok {
+		return streamer.GenerateStream(ctx, req, onToken)
+	}
+
+	response, err := provider.Generate(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if err := onToken(response); err != nil {
+		return response, err
+	}
+	return response, nil
+}
+
+// Settings descreve o modelo e os parâmetros de amostragem de um provedor
+type Settings struct {
+	Provider    string  `json:"provider"`
+	Model       string  `json:"model"`
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+}
+
+// wrapper é implementado pelos decoradores (tempo limite, limite de concorrência, retry, breaker)
+type wrapper interface {
+	unwrap() Provider
+}
+
+// Describe retorna as configurações do provedor, atravessando os decoradores;
+// provedores que não as informam são descritos apenas pelo nome
+func Describe(provider Provider) Settings {
+	for provider != nil {
+		switch p := provider.(type) {
+		case interface{ Settings() Settings }:
+			return p.Settings()
+		case wrapper:
+			provider = p.unwrap()
+		default:
+			return Settings{Provider: p.Name()}
+		}
+	}
+	return Settings{}
+}
+
+// Config configuração do provedor de LLM
+type Config struct {
+	Provider    string // ollama, openai ou fake
+	BaseURL     string
+	Model       string
+	APIKey      string // usado apenas por endpoints compatíveis com OpenAI
+	Temperature float64
+	TopP        float64
+
+	// Timeout limita cada tentativa de chamada ao modelo; zero desabilita o limite
+	Timeout time.Duration
+
+	// MaxConcurrency limita as chamadas simultâneas ao modelo; zero desabilita o limite
+	MaxConcurrency int
+
+	// Retry repete as chamadas em erros transitórios e Breaker interrompe as
+	// chamadas depois de falhas seguidas; valores zero desabilitam cada um
+	Retry   RetryConfig
+	Breaker BreakerConfig
+
+	// HTTPClient usado nas chamadas; http.DefaultClient quando nil
+	HTTPClient *http.Client
+}
+
+// DefaultConfig configuração padrão (Ollama local)
+var DefaultConfig = Config{
+	Provider:       ProviderOllama,
+	BaseURL:        "http://localhost:11434",
+	Model:          "llama3.2",
+	Temperature:    0.7,
+	TopP:           0.9,
+	Timeout:        60 * time.Second,
+	MaxConcurrency: 4,
+	Retry:          DefaultRetryConfig,
+	Breaker:        DefaultBreakerConfig,
+}
+
+// New cria o provedor indicado na configuração, decorado com tempo limite por
+// tentativa, limite de chamadas simultâneas, novas tentativas e circuit breaker
+// (nesta ordem, de dentro para fora: a espera por uma vaga não conta no tempo
+// limite, cada tentativa ocupa sua própria vaga e uma chamada que esgota as
+// tentativas conta como uma falha no breaker)
+func New(cfg Config) (Provider, error) {
+	provider, err := newProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	provider = WithTimeout(provider, cfg.Timeout)
+	provider = WithConcurrencyLimit(provider, cfg.MaxConcurrency)
+	provider = WithRetry(provider, cfg.Retry)
+	return WithBreaker(provider, NewCircuitBreaker(cfg.Breaker)), nil
+}
+
+// newProvider cria a implementação do provedor, sem decoradores
+func newProvider(cfg Config) (Provider, error) {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderOllama, "":
+		return NewOllama(cfg), nil
+	case ProviderOpenAI:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("provedor openai exige a URL base do endpoint")
+		}
+		return NewOpenAI(cfg), nil
+	case ProviderFake:
+		return &Fake{}, nil
+	default:
+		return nil, fmt.Errorf("provedor de LLM desconhecido: %s", cfg.Provider)
+	}
+}
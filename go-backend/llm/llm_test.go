@@ -0,0 +1,145 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	provider, err := New(Config{Provider: ProviderOllama, BaseURL: "http://ollama:11434/", Model: "llama3.2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ollama/llama3.2", provider.Name())
+	assert.Equal(t, "http://ollama:11434", provider.(*Ollama).config.BaseURL)
+
+	provider, err = New(Config{Provider: ProviderOllama, Model: "llama3.2", Timeout: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, "ollama/llama3.2", provider.Name())
+	assert.IsType(t, &timeoutProvider{}, provider)
+
+	provider, err = New(Config{Provider: "OpenAI", BaseURL: "http://localhost:8000/v1", Model: "qwen2.5"})
+	assert.NoError(t, err)
+	assert.Equal(t, "openai/qwen2.5", provider.Name())
+
+	provider, err = New(Config{Provider: ProviderFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "fake", provider.Name())
+
+	_, err = New(Config{Provider: ProviderOpenAI})
+	assert.Error(t, err)
+
+	_, err = New(Config{Provider: "gpt"})
+	assert.Error(t, err)
+}
+
+func TestFake(t *testing.T) {
+	fake := &Fake{}
+	ctx := context.Background()
+
+	first, err := fake.Generate(ctx, Prompt("Analise o jogador"))
+	assert.NoError(t, err)
+	second, _ := fake.Generate(ctx, Prompt("Analise o jogador"))
+	assert.Equal(t, first, second)
+
+	fake.Response = "Resposta fixa"
+	response, _ := fake.Generate(ctx, Prompt("outro prompt"))
+	assert.Equal(t, "Resposta fixa", response)
+
+	fake.Err = errors.New("indisponível")
+	_, err = fake.Generate(ctx, Prompt("outro prompt"))
+	assert.Error(t, err)
+
+	requests := fake.Requests()
+	assert.Len(t, requests, 4)
+	assert.Equal(t, RoleUser, requests[0].Messages[0].Role)
+	assert.Equal(t, "Analise o jogador", requests[0].Messages[0].Content)
+}
+
+func TestFakeStructured(t *testing.T) {
+	fake := &Fake{}
+	request := Prompt("Analise o jogador")
+	request.Format = json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"summary": {"type": "string"},
+			"strengths": {"type": "array", "items": {"type": "string"}},
+			"level": {"type": "string", "enum": ["elite", "serie_a"]},
+			"rating": {"type": "integer", "minimum": 1}
+		}
+	}`)
+
+	response, err := fake.Generate(context.Background(), request)
+	assert.NoError(t, err)
+
+	var object struct {
+		Summary   string   `json:"summary"`
+		Strengths []string `json:"strengths"`
+		Level     string   `json:"level"`
+		Rating    int      `json:"rating"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(response), &object))
+	assert.Contains(t, object.Summary, "Análise simulada")
+	assert.Len(t, object.Strengths, 1)
+	assert.Equal(t, "elite", object.Level)
+	assert.Equal(t, 1, object.Rating)
+
+	// O texto cita o primeiro dado entre « » das mensagens, ou Subject
+	response, _ = fake.Generate(context.Background(), Prompt("Analise o jogador «João Silva» do «Flamengo»"))
+	assert.Contains(t, response, "Análise simulada de João Silva")
+	response, _ = (&Fake{Subject: "Pedro Santos"}).Generate(context.Background(), Prompt("Analise «João Silva»"))
+	assert.Contains(t, response, "Análise simulada de Pedro Santos")
+
+	// Responses são usadas em ordem, antes de Response
+	fake = &Fake{Responses: []string{"primeira", "segunda"}, Response: "demais"}
+	for _, expected := range []string{"primeira", "segunda", "demais"} {
+		response, _ := fake.Generate(context.Background(), Prompt("Analise"))
+		assert.Equal(t, expected, response)
+	}
+}
+
+// generateOnly implementa apenas Provider, sem streaming
+type generateOnly struct{ response string }
+
+func (g *generateOnly) Name() string { return "generate-only" }
+
+func (g *generateOnly) Generate(ctx context.Context, req Request) (string, error) {
+	return g.response, nil
+}
+
+func TestStreamWithoutStreamingProvider(t *testing.T) {
+	var provider Provider = &generateOnly{response: "Resposta inteira"}
+	_, streams := provider.(StreamProvider)
+	assert.False(t, streams)
+
+	var tokens []string
+	response, err := Stream(context.Background(), provider, Prompt("Analise"), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Resposta inteira", response)
+	assert.Equal(t, []string{"Resposta inteira"}, tokens)
+}
+
+func TestDescribe(t *testing.T) {
+	provider, err := New(Config{
+		Provider:    ProviderOllama,
+		Model:       "llama3.2",
+		Temperature: 0.3,
+		TopP:        0.8,
+		Timeout:     time.Second,
+		Retry:       DefaultRetryConfig,
+		Breaker:     DefaultBreakerConfig,
+	})
+	assert.NoError(t, err)
+
+	// Os decoradores são atravessados até o provedor real
+	assert.Equal(t, Settings{Provider: ProviderOllama, Model: "llama3.2", Temperature: 0.3, TopP: 0.8}, Describe(provider))
+	assert.Equal(t, Settings{Provider: "generate-only"}, Describe(&generateOnly{}))
+	assert.Equal(t, Settings{}, Describe(nil))
+}
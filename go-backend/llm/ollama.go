@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ollama chama a API de chat de um servidor Ollama
+type Ollama struct {
+	config Config
+}
+
+// ollamaChatRequest representa a requisição para /api/chat
+type ollamaChatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+
+	// Format é o JSON Schema da resposta estruturada
+	Format  json.RawMessage `json:"format,omitempty"`
+	Options struct {
+		Temperature float64 `json:"temperature"`
+		TopP        float64 `json:"top_p"`
+	} `json:"options"`
+}
+
+// ollamaChatResponse representa a resposta de /api/chat
+type ollamaChatResponse struct {
+	Model   string  `json:"model"`
+	Message Message `json:"message"`
+	Done    bool    `json:"done"`
+}
+
+// NewOllama cria o provedor Ollama
+func NewOllama(cfg Config) *Ollama {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Ollama{config: cfg}
+}
+
+// Name identifica o provedor e o modelo
+func (o *Ollama) Name() string {
+	return ProviderOllama + "/" + o.config.Model
+}
+
+// Settings retorna o modelo e os parâmetros de amostragem
+func (o *Ollama) Settings() Settings {
+	return Settings{Provider: ProviderOllama, Model: o.config.Model, Temperature: o.config.Temperature, TopP: o.config.TopP}
+}
+
+// Generate envia as mensagens ao Ollama e retorna a resposta completa
+func (o *Ollama) Generate(ctx context.Context, req Request) (string, error) {
+	requestBody := ollamaChatRequest{
+		Model:    o.config.Model,
+		Messages: req.Messages,
+		Stream:   false,
+		Format:   req.Format,
+	}
+	requestBody.Options.Temperature = o.config.Temperature
+	requestBody.Options.TopP = o.config.TopP
+
+	var response ollamaChatResponse
+	if err := postJSON(ctx, o.config.HTTPClient, o.config.BaseURL+"/api/chat", nil, requestBody, &response); err != nil {
+		return "", fmt.Errorf("ollama: %w", err)
+	}
+	if strings.TrimSpace(response.Message.Content) == "" {
+		return "", fmt.Errorf("ollama: %w: resposta vazia", ErrInvalidOutput)
+	}
+	return response.Message.Content, nil
+}
+
+// GenerateStream envia as mensagens com stream habilitado e repassa cada trecho
+// da resposta (NDJSON) a onToken à medida que chega
+func (o *Ollama) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	requestBody := ollamaChatRequest{
+		Model:    o.config.Model,
+		Messages: req.Messages,
+		Stream:   true,
+		Format:   req.Format,
+	}
+	requestBody.Options.Temperature = o.config.Temperature
+	requestBody.Options.TopP = o.config.TopP
+
+	resp, err := post(ctx, o.config.HTTPClient, o.config.BaseURL+"/api/chat", nil, requestBody)
+	if err != nil {
+		return "", fmt.Errorf("ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var full strings.Builder
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var chunk ollamaChatResponse
+		if err := decoder.Decode(&chunk); err != nil {
+			if err == io.EOF {
+				return full.String(), fmt.Errorf("ollama: stream encerrado antes do fim da resposta")
+			}
+			return full.String(), fmt.Errorf("ollama: %w: erro ao ler stream: %v", ErrInvalidOutput, err)
+		}
+
+		if chunk.Message.Content != "" {
+			full.WriteString(chunk.Message.Content)
+			if err := onToken(chunk.Message.Content); err != nil {
+				return full.String(), err
+			}
+		}
+		if chunk.Done {
+			return full.String(), nil
+		}
+	}
+}
+
+// StatusError representa uma resposta HTTP de erro do servidor do modelo
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("erro do servidor (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Is faz errors.Is(err, ErrModelMissing) reconhecer o 404 que Ollama e os
+// servidores compatíveis com OpenAI retornam para modelos não instalados
+func (e *StatusError) Is(target error) bool {
+	return target == ErrModelMissing && e.StatusCode == http.StatusNotFound
+}
+
+// postJSON envia body como JSON e decodifica a resposta em out
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	resp, err := post(ctx, client, url, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: erro ao decodificar resposta: %v", ErrInvalidOutput, err)
+	}
+	return nil
+}
+
+// post envia body como JSON e retorna a resposta quando o status é 200;
+// o chamador deve fechar o corpo da resposta
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar requisição: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("erro na requisição HTTP: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(responseBody)}
+	}
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOllamaGenerate(t *testing.T) {
+	var received ollamaChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&received)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"model":   "llama3.2",
+			"message": map[string]string{"role": "assistant", "content": "Atacante com bom potencial"},
+			"done":    true,
+		})
+	}))
+	defer server.Close()
+
+	provider := NewOllama(Config{BaseURL: server.URL, Model: "llama3.2", Temperature: 0.2, TopP: 0.8})
+	response, err := provider.Generate(context.Background(), Prompt("Analise o jogador"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Atacante com bom potencial", response)
+	assert.Equal(t, "llama3.2", received.Model)
+	assert.False(t, received.Stream)
+	assert.Equal(t, 0.2, received.Options.Temperature)
+	assert.Equal(t, []Message{{Role: RoleUser, Content: "Analise o jogador"}}, received.Messages)
+	assert.Empty(t, received.Format)
+}
+
+func TestOllamaGenerateStructured(t *testing.T) {
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"message":{"role":"assistant","content":"{\"summary\":\"Atacante\"}"},"done":true}`))
+	}))
+	defer server.Close()
+
+	schema := json.RawMessage(`{"type":"object","properties":{"summary":{"type":"string"}}}`)
+	request := Prompt("Analise o jogador")
+	request.Format = schema
+	response, err := NewOllama(Config{BaseURL: server.URL, Model: "llama3.2"}).Generate(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"summary":"Atacante"}`, response)
+	assert.JSONEq(t, string(schema), string(received["format"]))
+}
+
+func TestOllamaGenerateServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := NewOllama(Config{BaseURL: server.URL, Model: "inexistente"})
+	_, err := provider.Generate(context.Background(), Prompt("Analise o jogador"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+	assert.ErrorIs(t, err, ErrModelMissing)
+}
+
+func TestOllamaGenerateInvalidOutput(t *testing.T) {
+	for name, body := range map[string]string{
+		"json inválido":  `{"message":`,
+		"conteúdo vazio": `{"message":{"role":"assistant","content":"  "},"done":true}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			provider := NewOllama(Config{BaseURL: server.URL, Model: "llama3.2"})
+			_, err := provider.Generate(context.Background(), Prompt("Analise o jogador"))
+
+			assert.ErrorIs(t, err, ErrInvalidOutput)
+			assert.NotErrorIs(t, err, ErrModelMissing)
+		})
+	}
+}
+
+func TestOllamaGenerateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received ollamaChatRequest
+		json.NewDecoder(r.Body).Decode(&received)
+		assert.True(t, received.Stream)
+
+		w.Write([]byte(`{"message":{"role":"assistant","content":"Atacante "},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":"veloz"},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":""},"done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	var tokens []string
+	provider := NewOllama(Config{BaseURL: server.URL, Model: "llama3.2"})
+	response, err := provider.GenerateStream(context.Background(), Prompt("Analise o jogador"), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Atacante veloz", response)
+	assert.Equal(t, []string{"Atacante ", "veloz"}, tokens)
+}
+
+func TestOllamaGenerateStreamInterrupted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":{"role":"assistant","content":"Atacante "},"done":false}` + "\n"))
+	}))
+	defer server.Close()
+
+	provider := NewOllama(Config{BaseURL: server.URL, Model: "llama3.2"})
+	response, err := provider.GenerateStream(context.Background(), Prompt("Analise o jogador"), func(string) error { return nil })
+
+	assert.Error(t, err)
+	assert.Equal(t, "Atacante ", response)
+}
@@ -0,0 +1,166 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAI chama qualquer endpoint compatível com a API de chat da OpenAI
+// (llama.cpp server, vLLM, LM Studio...). BaseURL inclui o prefixo da versão,
+// ex.: http://localhost:8000/v1
+type OpenAI struct {
+	config Config
+}
+
+// openAIChatRequest representa a requisição para /chat/completions
+type openAIChatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Temperature float64   `json:"temperature"`
+	TopP        float64   `json:"top_p"`
+	Stream      bool      `json:"stream"`
+
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+// openAIResponseFormat pede a resposta estruturada segundo um JSON Schema
+type openAIResponseFormat struct {
+	Type       string `json:"type"`
+	JSONSchema struct {
+		Name   string          `json:"name"`
+		Schema json.RawMessage `json:"schema"`
+	} `json:"json_schema"`
+}
+
+// newOpenAIResponseFormat converte o schema da requisição; nil para texto livre
+func newOpenAIResponseFormat(schema json.RawMessage) *openAIResponseFormat {
+	if len(schema) == 0 {
+		return nil
+	}
+	format := &openAIResponseFormat{Type: "json_schema"}
+	format.JSONSchema.Name = "response"
+	format.JSONSchema.Schema = schema
+	return format
+}
+
+// openAIStreamChunk representa um evento do stream de /chat/completions
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// openAIChatResponse representa a resposta de /chat/completions
+type openAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      Message `json:"message"`
+		FinishReason string  `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// NewOpenAI cria o provedor compatível com OpenAI
+func NewOpenAI(cfg Config) *OpenAI {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &OpenAI{config: cfg}
+}
+
+// Name identifica o provedor e o modelo
+func (o *OpenAI) Name() string {
+	return ProviderOpenAI + "/" + o.config.Model
+}
+
+// Settings retorna o modelo e os parâmetros de amostragem
+func (o *OpenAI) Settings() Settings {
+	return Settings{Provider: ProviderOpenAI, Model: o.config.Model, Temperature: o.config.Temperature, TopP: o.config.TopP}
+}
+
+// Generate envia as mensagens ao endpoint e retorna a primeira resposta
+func (o *OpenAI) Generate(ctx context.Context, req Request) (string, error) {
+	requestBody := openAIChatRequest{
+		Model:       o.config.Model,
+		Messages:    req.Messages,
+		Temperature: o.config.Temperature,
+		TopP:        o.config.TopP,
+
+		ResponseFormat: newOpenAIResponseFormat(req.Format),
+	}
+
+	var response openAIChatResponse
+	if err := postJSON(ctx, o.config.HTTPClient, o.config.BaseURL+"/chat/completions", o.headers(), requestBody, &response); err != nil {
+		return "", fmt.Errorf("openai: %w", err)
+	}
+	if len(response.Choices) == 0 || strings.TrimSpace(response.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("openai: %w: resposta sem conteúdo", ErrInvalidOutput)
+	}
+	return response.Choices[0].Message.Content, nil
+}
+
+// GenerateStream envia as mensagens com stream habilitado e repassa cada trecho
+// da resposta (eventos "data:" no formato SSE) a onToken à medida que chega
+func (o *OpenAI) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	requestBody := openAIChatRequest{
+		Model:       o.config.Model,
+		Messages:    req.Messages,
+		Temperature: o.config.Temperature,
+		TopP:        o.config.TopP,
+		Stream:      true,
+
+		ResponseFormat: newOpenAIResponseFormat(req.Format),
+	}
+
+	resp, err := post(ctx, o.config.HTTPClient, o.config.BaseURL+"/chat/completions", o.headers(), requestBody)
+	if err != nil {
+		return "", fmt.Errorf("openai: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return full.String(), nil
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return full.String(), fmt.Errorf("openai: %w: evento inválido no stream: %v", ErrInvalidOutput, err)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			full.WriteString(choice.Delta.Content)
+			if err := onToken(choice.Delta.Content); err != nil {
+				return full.String(), err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("openai: erro ao ler stream: %w", err)
+	}
+	return full.String(), fmt.Errorf("openai: stream encerrado antes do fim da resposta")
+}
+
+// headers retorna os headers de autenticação, quando há chave configurada
+func (o *OpenAI) headers() map[string]string {
+	if o.config.APIKey == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + o.config.APIKey}
+}
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAIGenerate(t *testing.T) {
+	var received openAIChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer segredo", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"model":"qwen2.5","choices":[{"message":{"role":"assistant","content":"Meio-campista criativo"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(Config{Provider: ProviderOpenAI, BaseURL: server.URL + "/v1/", Model: "qwen2.5", APIKey: "segredo"})
+	assert.NoError(t, err)
+
+	request := Request{Messages: []Message{
+		{Role: RoleSystem, Content: "Você é um scout"},
+		{Role: RoleUser, Content: "Analise o jogador"},
+	}}
+	response, err := provider.Generate(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Meio-campista criativo", response)
+	assert.Equal(t, "qwen2.5", received.Model)
+	assert.Equal(t, request.Messages, received.Messages)
+	assert.Nil(t, received.ResponseFormat)
+}
+
+func TestOpenAIGenerateStructured(t *testing.T) {
+	var received openAIChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"summary\":\"Atacante\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	schema := json.RawMessage(`{"type":"object","properties":{"summary":{"type":"string"}}}`)
+	request := Prompt("Analise o jogador")
+	request.Format = schema
+	_, err := NewOpenAI(Config{BaseURL: server.URL, Model: "qwen2.5"}).Generate(context.Background(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, received.ResponseFormat) {
+		assert.Equal(t, "json_schema", received.ResponseFormat.Type)
+		assert.JSONEq(t, string(schema), string(received.ResponseFormat.JSONSchema.Schema))
+	}
+}
+
+func TestOpenAIGenerateNoChoices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAI(Config{BaseURL: server.URL, Model: "qwen2.5"})
+	_, err := provider.Generate(context.Background(), Prompt("Analise o jogador"))
+
+	assert.ErrorIs(t, err, ErrInvalidOutput)
+}
+
+func TestOpenAIGenerateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received openAIChatRequest
+		json.NewDecoder(r.Body).Decode(&received)
+		assert.True(t, received.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n"))
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"Meio-campista \"}}]}\n\n"))
+		w.Write([]byte(": keep-alive\n\n"))
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"criativo\"},\"finish_reason\":\"stop\"}]}\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	var tokens []string
+	provider := NewOpenAI(Config{BaseURL: server.URL, Model: "qwen2.5"})
+	response, err := Stream(context.Background(), provider, Prompt("Analise o jogador"), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Meio-campista criativo", response)
+	assert.Equal(t, []string{"Meio-campista ", "criativo"}, tokens)
+}
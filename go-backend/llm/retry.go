@@ -0,0 +1,125 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryConfig configura as novas tentativas em erros transitórios
+type RetryConfig struct {
+	MaxRetries int           // tentativas além da primeira; zero desabilita
+	BaseDelay  time.Duration // espera base, dobrada a cada tentativa
+	MaxDelay   time.Duration // teto da espera entre tentativas
+}
+
+// DefaultRetryConfig configuração padrão
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries: 2,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   5 * time.Second,
+}
+
+// retryProvider repete as chamadas que falham por erros transitórios
+type retryProvider struct {
+	provider Provider
+	config   RetryConfig
+}
+
+// WithRetry repete as chamadas ao provedor em erros transitórios (falha de
+// rede, 429 e 5xx), com backoff exponencial e jitter. O tempo limite de uma
+// tentativa e o cancelamento pelo chamador não são repetidos
+func WithRetry(provider Provider, config RetryConfig) Provider {
+	if config.MaxRetries <= 0 {
+		return provider
+	}
+	return &retryProvider{provider: provider, config: config}
+}
+
+// unwrap retorna o provedor decorado
+func (r *retryProvider) unwrap() Provider {
+	return r.provider
+}
+
+// Name identifica o provedor decorado
+func (r *retryProvider) Name() string {
+	return r.provider.Name()
+}
+
+// Generate chama o provedor, repetindo em erros transitórios
+func (r *retryProvider) Generate(ctx context.Context, req Request) (string, error) {
+	var response string
+	err := r.do(ctx, func() (bool, error) {
+		var err error
+		response, err = r.provider.Generate(ctx, req)
+		return true, err
+	})
+	return response, err
+}
+
+// GenerateStream chama o provedor em streaming; só repete enquanto nenhum
+// trecho foi entregue, para o cliente não receber texto duplicado
+func (r *retryProvider) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	var response string
+	err := r.do(ctx, func() (bool, error) {
+		delivered := false
+		var err error
+		response, err = Stream(ctx, r.provider, req, func(token string) error {
+			delivered = true
+			return onToken(token)
+		})
+		return !delivered, err
+	})
+	return response, err
+}
+
+// do executa attempt até ter sucesso, o erro não ser transitório, attempt
+// indicar que não pode ser repetida ou as tentativas acabarem
+func (r *retryProvider) do(ctx context.Context, attempt func() (retryable bool, err error)) error {
+	for retry := 0; ; retry++ {
+		retryable, err := attempt()
+		if err == nil || !retryable || retry >= r.config.MaxRetries || ctx.Err() != nil || !isTransient(err) {
+			return err
+		}
+
+		timer := time.NewTimer(r.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff calcula a espera antes da próxima tentativa ("full jitter": valor
+// aleatório até o dobro da espera anterior), para os clientes não repetirem juntos
+func (r *retryProvider) backoff(retry int) time.Duration {
+	limit := r.config.BaseDelay << retry
+	if limit <= 0 || limit > r.config.MaxDelay {
+		limit = r.config.MaxDelay
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(limit)) + 1)
+}
+
+// isTransient indica se vale tentar de novo: falhas de rede, 429 e 5xx
+func isTransient(err error) bool {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
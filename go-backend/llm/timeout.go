@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout indica que a chamada ao LLM excedeu o tempo limite configurado
+var ErrTimeout = errors.New("tempo limite do LLM excedido")
+
+// timeoutProvider limita a duração de cada chamada ao provedor
+type timeoutProvider struct {
+	provider Provider
+	timeout  time.Duration
+}
+
+// WithTimeout limita cada chamada ao provedor (inclusive streaming, do início ao
+// fim) a timeout; o contexto do chamador continua valendo, então a chamada
+// também é interrompida quando o cliente desconecta
+func WithTimeout(provider Provider, timeout time.Duration) Provider {
+	if timeout <= 0 {
+		return provider
+	}
+	return &timeoutProvider{provider: provider, timeout: timeout}
+}
+
+// unwrap retorna o provedor decorado
+func (t *timeoutProvider) unwrap() Provider {
+	return t.provider
+}
+
+// Name identifica o provedor decorado
+func (t *timeoutProvider) Name() string {
+	return t.provider.Name()
+}
+
+// Generate chama o provedor com o prazo configurado
+func (t *timeoutProvider) Generate(ctx context.Context, req Request) (string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	response, err := t.provider.Generate(callCtx, req)
+	return response, t.wrap(ctx, callCtx, err)
+}
+
+// GenerateStream chama o provedor em streaming com o prazo configurado
+func (t *timeoutProvider) GenerateStream(ctx context.Context, req Request, onToken func(string) error) (string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	response, err := Stream(callCtx, t.provider, req, onToken)
+	return response, t.wrap(ctx, callCtx, err)
+}
+
+// wrap identifica o estouro do prazo desta chamada, distinguindo-o do
+// cancelamento pelo chamador
+func (t *timeoutProvider) wrap(ctx, callCtx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w (%s): %v", ErrTimeout, t.timeout, err)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutExceeded(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	provider := WithTimeout(NewOllama(Config{BaseURL: server.URL, Model: "llama3.2"}), 50*time.Millisecond)
+
+	started := time.Now()
+	_, err := provider.Generate(context.Background(), Prompt("Analise o jogador"))
+
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Less(t, time.Since(started), 2*time.Second)
+}
+
+func TestWithTimeoutCallerCanceled(t *testing.T) {
+	provider := WithTimeout(&Fake{Response: "ok"}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := provider.Generate(ctx, Prompt("Analise o jogador"))
+
+	// Cancelamento pelo chamador não é tempo limite
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.Is(err, ErrTimeout))
+}
+
+func TestWithTimeoutStream(t *testing.T) {
+	provider := WithTimeout(&Fake{Response: "Atacante veloz"}, time.Minute)
+	_, streams := provider.(StreamProvider)
+	assert.True(t, streams)
+
+	var tokens []string
+	response, err := Stream(context.Background(), provider, Prompt("Analise"), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Atacante veloz", response)
+	assert.Equal(t, []string{"Atacante ", "veloz"}, tokens)
+}
@@ -0,0 +1,74 @@
+package models
+
+import "gorm.io/gorm"
+
+// Analysis guarda uma análise gerada para o jogador, com os dados do jogador
+// e a configuração do modelo no momento da geração, para auditoria
+type Analysis struct {
+	gorm.Model
+	PlayerID uint   `json:"player_id" gorm:"not null;index"`
+	Season   string `json:"season,omitempty"`
+
+	// Resultado da análise
+	Rating   int      `json:"rating"`
+	Text     string   `json:"analysis" gorm:"type:text"`
+	Insights []string `json:"insights" gorm:"type:text;serializer:json"`
+	AIUsed   bool     `json:"ai_used"`
+	AIStatus string   `json:"ai_status,omitempty"` // motivo quando a IA foi pedida e não usada
+	Lang     string   `json:"lang,omitempty"`      // idioma dos textos
+
+	// Análise estruturada devolvida pela IA; ausente na análise estática
+	Report *ScoutReport `json:"report,omitempty" gorm:"type:text;serializer:json"`
+
+	// Jogador e estatísticas usados na análise
+	Player PlayerSnapshot `json:"player" gorm:"type:text;serializer:json"`
+
+	// Modelo que gerou o texto; vazios na análise estática
+	Provider      string  `json:"provider,omitempty"`
+	ModelName     string  `json:"model,omitempty"`
+	PromptVersion string  `json:"prompt_version,omitempty"`
+	Temperature   float64 `json:"temperature"`
+}
+
+// Níveis de competição aceitos em ScoutReport.RecommendedLevel
+const (
+	LevelElite  = "elite" // clubes de ponta no Brasil e no exterior
+	LevelSerieA = "serie_a"
+	LevelSerieB = "serie_b"
+	LevelSerieC = "serie_c"
+	LevelSerieD = "serie_d"
+)
+
+// RecommendedLevels lista os níveis de competição, do mais alto ao mais baixo
+var RecommendedLevels = []string{LevelElite, LevelSerieA, LevelSerieB, LevelSerieC, LevelSerieD}
+
+// ScoutReport é a análise estruturada do jogador gerada pela IA
+type ScoutReport struct {
+	Summary          string   `json:"summary"`
+	Strengths        []string `json:"strengths"`
+	Weaknesses       []string `json:"weaknesses"`
+	RecommendedLevel string   `json:"recommended_level"`
+	RiskFlags        []string `json:"risk_flags"`
+	SuggestedRating  int      `json:"suggested_rating"` // 1-10
+}
+
+// PlayerSnapshot são os dados do jogador no momento de uma análise
+type PlayerSnapshot struct {
+	Name            string  `json:"name"`
+	Age             int     `json:"age"`
+	Position        string  `json:"position"`
+	Team            string  `json:"team"`
+	Goals           int     `json:"goals"`
+	Tackles         int     `json:"tackles"`
+	Passes          int     `json:"passes"`
+	Version         int     `json:"version"`
+	Games           int     `json:"games"`
+	MinutesPlayed   int     `json:"minutes_played"`
+	Efficiency      float64 `json:"efficiency"`
+	PerformanceRank string  `json:"performance_rank"`
+}
+
+// TableName especifica o nome da tabela
+func (Analysis) TableName() string {
+	return "analyses"
+}
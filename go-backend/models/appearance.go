@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Appearance representa a atuação de um jogador em uma partida
+type Appearance struct {
+	gorm.Model
+	PlayerID    uint      `json:"player_id" gorm:"not null;index"`
+	MatchDate   time.Time `json:"match_date" binding:"required" gorm:"not null;index"`
+	Opponent    string    `json:"opponent" binding:"required" gorm:"not null"`
+	Competition string    `json:"competition" gorm:"default:''"`
+	Minutes     int       `json:"minutes" binding:"min=0,max=150" gorm:"default:0"`
+	Goals       int       `json:"goals" binding:"min=0" gorm:"default:0"`
+	Tackles     int       `json:"tackles" binding:"min=0" gorm:"default:0"`
+	Passes      int       `json:"passes" binding:"min=0" gorm:"default:0"`
+}
+
+// TableName especifica o nome da tabela
+func (Appearance) TableName() string {
+	return "appearances"
+}
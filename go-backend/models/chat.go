@@ -0,0 +1,48 @@
+package models
+
+import "gorm.io/gorm"
+
+// Papéis das mensagens de uma conversa com o assistente de scouting
+const (
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+// ChatSession é uma conversa do scout com o assistente; o histórico fica no
+// banco e é reenviado ao modelo a cada pergunta
+type ChatSession struct {
+	gorm.Model
+	Title string `json:"title"`
+	Lang  string `json:"lang"` // idioma das respostas
+
+	// Jogadores em foco na conversa, do mais antigo ao mais recente; os dados e
+	// as últimas análises deles vão como contexto para o modelo
+	PlayerIDs []uint `json:"player_ids" gorm:"type:text;serializer:json"`
+
+	Messages []ChatMessage `json:"messages,omitempty" gorm:"foreignKey:SessionID"`
+}
+
+// ChatMessage é uma pergunta do scout ou uma resposta do assistente
+type ChatMessage struct {
+	gorm.Model
+	SessionID uint   `json:"session_id" gorm:"not null;index"`
+	Role      string `json:"role" gorm:"not null"`
+	Content   string `json:"content" gorm:"type:text"`
+
+	// Jogadores enviados como contexto ao modelo nesta resposta
+	PlayerIDs []uint `json:"player_ids,omitempty" gorm:"type:text;serializer:json"`
+
+	// Modelo que gerou a resposta; vazios nas perguntas
+	Provider  string `json:"provider,omitempty"`
+	ModelName string `json:"model,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (ChatSession) TableName() string {
+	return "chat_sessions"
+}
+
+// TableName especifica o nome da tabela
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
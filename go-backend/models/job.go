@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de um lote de análises e de cada jogador do lote
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+
+	JobItemPending = "pending"
+	JobItemRunning = "running"
+	JobItemDone    = "done"
+	JobItemFailed  = "failed"
+)
+
+// AnalysisJob é um lote de análises processado em segundo plano; o estado fica
+// no banco para que o processamento continue depois de um reinício
+type AnalysisJob struct {
+	gorm.Model
+	Status   string `json:"status" gorm:"not null;index"`
+	AI       bool   `json:"ai"`
+	StrictAI bool   `json:"strict_ai"`
+	Lang     string `json:"lang"` // idioma das análises; vazio usa o padrão
+
+	// Progresso: Processed inclui os jogadores com falha
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Failed    int `json:"failed"`
+
+	Error      string     `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	Items []AnalysisJobItem `json:"items,omitempty" gorm:"foreignKey:JobID"`
+}
+
+// AnalysisJobItem é a análise de um jogador dentro do lote
+type AnalysisJobItem struct {
+	gorm.Model
+	JobID    uint   `json:"job_id" gorm:"not null;index"`
+	PlayerID uint   `json:"player_id" gorm:"not null"`
+	Status   string `json:"status" gorm:"not null;index"`
+	Error    string `json:"error,omitempty"`
+
+	// Passadas em que o jogador começou a ser analisado, inclusive as que não
+	// conseguiram gravar o andamento
+	Attempts int `json:"attempts"`
+
+	// Análise gravada no histórico quando o jogador foi processado
+	AnalysisID *uint     `json:"analysis_id,omitempty"`
+	Analysis   *Analysis `json:"analysis,omitempty" gorm:"foreignKey:AnalysisID;constraint:OnDelete:SET NULL"`
+}
+
+// TableName especifica o nome da tabela
+func (AnalysisJob) TableName() string {
+	return "analysis_jobs"
+}
+
+// TableName especifica o nome da tabela
+func (AnalysisJobItem) TableName() string {
+	return "analysis_job_items"
+}
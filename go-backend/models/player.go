@@ -11,6 +11,20 @@ type Player struct {
 	Goals    int    `json:"goals" binding:"min=0" gorm:"default:0"`
 	Tackles  int    `json:"tackles" binding:"min=0" gorm:"default:0"`
 	Passes   int    `json:"passes" binding:"min=0" gorm:"default:0"`
+
+	// Incrementada a cada alteração; base do ETag usado no controle de concorrência
+	Version int `json:"version" gorm:"not null;default:1"`
+
+	// Atuações partida a partida; quando existem, Goals/Tackles/Passes
+	// passam a ser os totais anteriores somados ao agregado delas e só mudam
+	// pelas atuações
+	Appearances []Appearance `json:"appearances,omitempty" binding:"-" gorm:"foreignKey:PlayerID"`
+
+	// Totais do cadastro guardados ao registrar a primeira atuação, para que
+	// o agregado das partidas não apague o histórico anterior
+	PriorGoals   int `json:"-" gorm:"not null;default:0"`
+	PriorTackles int `json:"-" gorm:"not null;default:0"`
+	PriorPasses  int `json:"-" gorm:"not null;default:0"`
 }
 
 // TableName especifica o nome da tabela
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Season representa uma temporada (ex.: 2025/26); as atuações pertencem à
+// temporada cujo intervalo de datas contém a data da partida
+type Season struct {
+	gorm.Model
+	Year      int       `json:"year" binding:"required,min=1900,max=2100" gorm:"not null;uniqueIndex"`
+	Name      string    `json:"name" gorm:"not null"`
+	StartDate time.Time `json:"start_date" binding:"required" gorm:"not null"`
+	EndDate   time.Time `json:"end_date" binding:"required" gorm:"not null"`
+}
+
+// TableName especifica o nome da tabela
+func (Season) TableName() string {
+	return "seasons"
+}
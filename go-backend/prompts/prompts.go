@@ -0,0 +1,289 @@
+// Package prompts carrega os templates dos prompts enviados ao LLM: os padrões
+// ficam embutidos no binário e podem ser substituídos por arquivos de um
+// diretório, recarregados sem reiniciar a API
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Nomes dos templates (arquivos <nome>.tmpl); as versões em outros idiomas
+// ficam em <nome>.<idioma>.tmpl, como player_analysis.en.tmpl
+const (
+	PlayerAnalysis      = "player_analysis"
+	ComparativeAnalysis = "comparative_analysis"
+	ChatAssistant       = "chat_assistant"
+)
+
+// SourceEmbedded identifica os templates embutidos no binário
+const SourceEmbedded = "embedded"
+
+// templateExt é a extensão dos arquivos de template
+const templateExt = ".tmpl"
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// versionPattern encontra a versão declarada no início do template, em um
+// comentário como {{- /* version: v2 */ -}}
+var versionPattern = regexp.MustCompile(`^\{\{-?\s*/\*\s*version:\s*([^\s*]+)\s*\*/\s*-?\}\}`)
+
+// Template é um template de prompt carregado
+type Template struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Checksum do conteúdo: muda a cada edição, mesmo sem mudar a versão
+	Checksum string    `json:"checksum"`
+	Source   string    `json:"source"` // "embedded" ou o caminho do arquivo
+	LoadedAt time.Time `json:"loaded_at"`
+
+	tmpl *template.Template
+}
+
+// Prompt é um template renderizado
+type Prompt struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Checksum string `json:"checksum"`
+	Lang     string `json:"lang,omitempty"` // idioma pedido em RenderLocale
+	Text     string `json:"text"`
+}
+
+// Registry guarda os templates em uso; é seguro para uso concorrente
+type Registry struct {
+	dir string
+
+	mu          sync.RWMutex
+	templates   map[string]*Template
+	fingerprint string
+}
+
+// New carrega os templates embutidos e os substitui pelos arquivos .tmpl de
+// dir com o mesmo nome; dir vazio usa apenas os embutidos
+func New(dir string) (*Registry, error) {
+	registry := &Registry{dir: dir}
+	if err := registry.Reload(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Default retorna um registro só com os templates embutidos
+func Default() *Registry {
+	registry, err := New("")
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// Reload relê os templates; se algum não compilar, os anteriores continuam em uso
+func (r *Registry) Reload() error {
+	fingerprint, err := r.scan()
+	if err != nil {
+		return err
+	}
+
+	templates := map[string]*Template{}
+	now := time.Now()
+	err = fs.WalkDir(embedded, "templates", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := embedded.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return addTemplate(templates, entry.Name(), content, SourceEmbedded, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.dir != "" {
+		paths, err := filepath.Glob(filepath.Join(r.dir, "*"+templateExt))
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err := addTemplate(templates, filepath.Base(path), content, path, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.mu.Lock()
+	r.templates = templates
+	r.fingerprint = fingerprint
+	r.mu.Unlock()
+	return nil
+}
+
+// addTemplate compila o conteúdo do arquivo e o registra pelo nome
+func addTemplate(templates map[string]*Template, file string, content []byte, source string, loadedAt time.Time) error {
+	name := strings.TrimSuffix(file, templateExt)
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("template %s (%s): %w", name, source, err)
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])[:12]
+	version := "sha-" + checksum[:8] // sem versão declarada, identifica pelo conteúdo
+	if match := versionPattern.FindSubmatch(bytes.TrimSpace(content)); match != nil {
+		version = string(match[1])
+	}
+
+	templates[name] = &Template{
+		Name:     name,
+		Version:  version,
+		Checksum: checksum,
+		Source:   source,
+		LoadedAt: loadedAt,
+		tmpl:     tmpl,
+	}
+	return nil
+}
+
+// scan resume nome, tamanho e data de modificação dos arquivos do diretório,
+// para detectar mudanças sem reler o conteúdo
+func (r *Registry) scan() (string, error) {
+	if r.dir == "" {
+		return "", nil
+	}
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return "", fmt.Errorf("diretório de prompts: %w", err)
+	}
+
+	var fingerprint strings.Builder
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return fingerprint.String(), nil
+}
+
+// Watch verifica o diretório a cada interval e recarrega os templates quando
+// algum arquivo muda, até ctx terminar; erros são registrados no log
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	if r.dir == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fingerprint, err := r.scan()
+		if err != nil {
+			log.Printf("Erro ao verificar os prompts: %v", err)
+			continue
+		}
+		r.mu.RLock()
+		changed := fingerprint != r.fingerprint
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			log.Printf("Erro ao recarregar os prompts (mantidos os anteriores): %v", err)
+			r.mu.Lock()
+			r.fingerprint = fingerprint // não tenta de novo até o arquivo mudar outra vez
+			r.mu.Unlock()
+			continue
+		}
+		log.Printf("Prompts recarregados de %s", r.dir)
+	}
+}
+
+// List retorna os templates carregados, em ordem de nome
+func (r *Registry) List() []Template {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Template, 0, len(r.templates))
+	for _, tmpl := range r.templates {
+		list = append(list, *tmpl)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get retorna o template pelo nome
+func (r *Registry) Get(name string) (Template, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tmpl, ok := r.templates[name]
+	if !ok {
+		return Template{}, false
+	}
+	return *tmpl, true
+}
+
+// RenderLocale executa a versão do template no idioma lang (<nome>.<lang>) ou,
+// se ela não existir, o template padrão
+func (r *Registry) RenderLocale(name, lang string, data interface{}) (Prompt, error) {
+	localized := name
+	if _, ok := r.Get(name + "." + lang); ok {
+		localized = name + "." + lang
+	}
+
+	prompt, err := r.Render(localized, data)
+	if err != nil {
+		return Prompt{}, err
+	}
+	prompt.Lang = lang
+	return prompt, nil
+}
+
+// Render executa o template com os dados informados
+func (r *Registry) Render(name string, data interface{}) (Prompt, error) {
+	tmpl, ok := r.Get(name)
+	if !ok {
+		return Prompt{}, fmt.Errorf("template de prompt não encontrado: %s", name)
+	}
+
+	var text strings.Builder
+	if err := tmpl.tmpl.Execute(&text, data); err != nil {
+		return Prompt{}, fmt.Errorf("erro ao renderizar o prompt %s: %w", name, err)
+	}
+	return Prompt{
+		Name:     tmpl.Name,
+		Version:  tmpl.Version,
+		Checksum: tmpl.Checksum,
+		Text:     strings.TrimSpace(text.String()),
+	}, nil
+}
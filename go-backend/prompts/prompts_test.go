@@ -0,0 +1,129 @@
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	registry := Default()
+
+	list := registry.List()
+	var names []string
+	for _, tmpl := range list {
+		names = append(names, tmpl.Name)
+	}
+	assert.Equal(t, []string{
+		ChatAssistant, ChatAssistant + ".en", ChatAssistant + ".es",
+		ComparativeAnalysis, ComparativeAnalysis + ".en", ComparativeAnalysis + ".es",
+		PlayerAnalysis, PlayerAnalysis + ".en", PlayerAnalysis + ".es",
+	}, names)
+	for _, tmpl := range list {
+		assert.Equal(t, SourceEmbedded, tmpl.Source)
+		assert.True(t, strings.HasPrefix(tmpl.Version, "v"))
+		assert.Len(t, tmpl.Checksum, 12)
+	}
+
+	_, err := registry.Render("inexistente", nil)
+	assert.Error(t, err)
+}
+
+func TestOverrideFromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, PlayerAnalysis, "{{- /* version: v3-curto */ -}}\nAnalise {{.Name}}.\n")
+	writeTemplate(t, dir, "sem_versao", "Olá {{.Name}}")
+
+	registry, err := New(dir)
+	assert.NoError(t, err)
+
+	prompt, err := registry.Render(PlayerAnalysis, struct{ Name string }{"João Silva"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v3-curto", prompt.Version)
+	assert.Equal(t, "Analise João Silva.", prompt.Text)
+
+	// Sem versão declarada, a versão vem do conteúdo
+	tmpl, ok := registry.Get("sem_versao")
+	assert.True(t, ok)
+	assert.Equal(t, "sha-"+tmpl.Checksum[:8], tmpl.Version)
+	assert.Equal(t, filepath.Join(dir, "sem_versao.tmpl"), tmpl.Source)
+
+	// Os templates que o diretório não substitui continuam os embutidos
+	tmpl, _ = registry.Get(ComparativeAnalysis)
+	assert.Equal(t, SourceEmbedded, tmpl.Source)
+
+	// Campos inexistentes são erro, não texto vazio
+	_, err = registry.Render(PlayerAnalysis, struct{ Other string }{"x"})
+	assert.Error(t, err)
+}
+
+func TestRenderLocale(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "saudacao", "Olá {{.Name}}")
+	writeTemplate(t, dir, "saudacao.en", "Hello {{.Name}}")
+
+	registry, err := New(dir)
+	assert.NoError(t, err)
+	data := struct{ Name string }{"João"}
+
+	prompt, err := registry.RenderLocale("saudacao", "en", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "saudacao.en", prompt.Name)
+	assert.Equal(t, "en", prompt.Lang)
+	assert.Equal(t, "Hello João", prompt.Text)
+
+	// Sem a versão no idioma, usa o template padrão
+	prompt, err = registry.RenderLocale("saudacao", "es", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "saudacao", prompt.Name)
+	assert.Equal(t, "es", prompt.Lang)
+	assert.Equal(t, "Olá João", prompt.Text)
+}
+
+func TestReloadKeepsPreviousOnError(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, PlayerAnalysis, "{{- /* version: v3 */ -}}\nAnalise {{.Name}}")
+	registry, err := New(dir)
+	assert.NoError(t, err)
+
+	writeTemplate(t, dir, PlayerAnalysis, "{{- /* version: v4 */ -}}\nAnalise {{.Name")
+	assert.Error(t, registry.Reload())
+
+	prompt, err := registry.Render(PlayerAnalysis, struct{ Name string }{"João"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v3", prompt.Version)
+
+	_, err = New(filepath.Join(dir, "inexistente"))
+	assert.Error(t, err)
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, PlayerAnalysis, "{{- /* version: v3 */ -}}\nAnalise {{.Name}}")
+	registry, err := New(dir)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Watch(ctx, 10*time.Millisecond)
+
+	writeTemplate(t, dir, PlayerAnalysis, "{{- /* version: v4 */ -}}\nAvalie o jogador {{.Name}}")
+	assert.Eventually(t, func() bool {
+		tmpl, _ := registry.Get(PlayerAnalysis)
+		return tmpl.Version == "v4"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	prompt, _ := registry.Render(PlayerAnalysis, struct{ Name string }{"João"})
+	assert.Equal(t, "Avalie o jogador João", prompt.Text)
+}
+
+// writeTemplate grava o arquivo do template no diretório
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name+templateExt), []byte(content), 0o644))
+}